@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"gRPC/pb"
 	"gRPC/sample"
+	"gRPC/service"
 	"io"
 	"log"
 	"os"
@@ -16,7 +17,6 @@ import (
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
 func CreateLaptop(laptopClient pb.LaptopServiceClient, laptop *pb.Laptop){
@@ -26,11 +26,11 @@ func CreateLaptop(laptopClient pb.LaptopServiceClient, laptop *pb.Laptop){
 
 	res, err := laptopClient.CreateLaptop(context.Background(),req)
 	if err != nil{
-		st, ok := status.FromError(err)
-		if ok && st.Code() == codes.AlreadyExists{
+		details := service.ParseErrorDetails(err)
+		if details.Code == codes.AlreadyExists{
 			log.Print("laptop already exists")
 		} else {
-			log.Fatal("can not create laptop", err)
+			log.Fatal("can not create laptop: ", details)
 		}
 		return 
 	}
@@ -38,6 +38,49 @@ func CreateLaptop(laptopClient pb.LaptopServiceClient, laptop *pb.Laptop){
 	log.Printf("laptop created with id %s", res.Id)
 }
 
+func BatchCreateLaptops(laptopClient pb.LaptopServiceClient, laptops []*pb.Laptop, allOrNothing bool){
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := laptopClient.BatchCreateLaptops(ctx)
+	if err != nil{
+		log.Fatal("cannot batch create laptops", err)
+	}
+
+	req := &pb.BatchCreateLaptopsRequest{
+		Data: &pb.BatchCreateLaptopsRequest_Options{
+			Options: &pb.BatchCreateOptions{AllOrNothing: allOrNothing},
+		},
+	}
+
+	err = stream.Send(req)
+	if err != nil{
+		log.Fatal("cannot send options", err)
+	}
+
+	for _, laptop := range laptops{
+		req := &pb.BatchCreateLaptopsRequest{
+			Data: &pb.BatchCreateLaptopsRequest_Laptop{
+				Laptop: laptop,
+			},
+		}
+
+		err = stream.Send(req)
+		if err != nil{
+			log.Fatal("cannot send laptop", err)
+		}
+	}
+
+	res, err := stream.CloseAndRecv()
+	if err != nil{
+		log.Fatal("cannot receive response: ", service.ParseErrorDetails(err))
+	}
+
+	for _, result := range res.GetResults(){
+		log.Printf("laptop %s: %s", result.GetId(), result.GetStatus())
+	}
+}
+
 func UploadImage(laptopClient pb.LaptopServiceClient, laptopID string, imagePath string){
 	file, err := os.Open(imagePath)
 	if err != nil{
@@ -90,7 +133,7 @@ func UploadImage(laptopClient pb.LaptopServiceClient, laptopID string, imagePath
 
 	res, err := stream.CloseAndRecv()
 		if err != nil{
-			log.Fatal("cannot received res")
+			log.Fatal("cannot upload image: ", service.ParseErrorDetails(err))
 		}
 
 		log.Print("image uploaded:", res.GetId(), res.GetSize())
@@ -118,7 +161,12 @@ func testSearchLaptop(laptopClient pb.LaptopServiceClient, filter *pb.Filter){
 		}
 
 		if err != nil{
-			log.Fatal("cannot receive response")
+			log.Fatal("cannot receive response: ", service.ParseErrorDetails(err))
+		}
+
+		if res.GetNextPageToken() != ""{
+			log.Println(" - next page: ", res.GetNextPageToken())
+			continue
 		}
 
 		laptop := res.GetLaptop()
@@ -153,7 +201,8 @@ func rateLaptop(laptopClient pb.LaptopServiceClient, laptopIDs []string, scores
 			}
 
 			if err != nil{
-				waitRespones <- fmt.Errorf("cannot received with err %v", err)
+				waitRespones <- fmt.Errorf("cannot received with err %v", service.ParseErrorDetails(err))
+				return
 			}
 
 			log.Print("received responsse: ", res)
@@ -215,9 +264,10 @@ func testRateLaptop(laptopClient pb.LaptopServiceClient) {
 			log.Fatal(err)
 		}
 
+		// rating a laptop that does not exist fails with NotFound
 		err = rateLaptop(laptopClient,[]string{""}, []float64{3.1})
 		if err != nil {
-			log.Fatal(err)
+			log.Print(err)
 		}
 	}
 }
@@ -234,9 +284,11 @@ func main(){
 
 	laptopClient := pb.NewLaptopServiceClient(conn)
 
+	laptops := []*pb.Laptop{}
 	for i:= 0; i < 10; i++ {
-		CreateLaptop(laptopClient, sample.NewLaptop())
+		laptops = append(laptops, sample.NewLaptop())
 	}
+	BatchCreateLaptops(laptopClient, laptops, false)
 
 	// filter := &pb.Filter{
 	// 	MaxPriceUsd: 3000,
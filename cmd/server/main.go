@@ -1,23 +1,52 @@
 package main
 
 import (
+	"context"
+	"database/sql"
 	"flag"
 	"fmt"
 	"gRPC/pb"
 	"gRPC/service"
 	"log"
 	"net"
+	"os"
+	"path/filepath"
+	"time"
 
+	_ "github.com/mattn/go-sqlite3"
 	"google.golang.org/grpc"
 )
 
+const imageFolder = "img"
+
 func main(){
 	port := flag.Int("port", 0, "the server port")
+	storeType := flag.String("store", "memory", "where laptops and ratings are stored: memory, file, wal, bolt or sql")
+	dataFolder := flag.String("data-folder", "data", "the folder of the file, wal, bolt and sql stores")
+	snapshotEvery := flag.Int("snapshot-every", 1000, "how many records the wal store logs between snapshots")
+	retention := flag.Duration("retention", 30*24*time.Hour, "how long deleted laptops are kept before they are purged")
+	purgeInterval := flag.Duration("purge-interval", time.Hour, "how often deleted laptops are purged, 0 disables purging")
 	flag.Parse()
 
+	if *snapshotEvery < 0{
+		log.Fatalf("invalid snapshot interval %d", *snapshotEvery)
+	}
+	if *purgeInterval < 0{
+		log.Fatalf("invalid purge interval %v", *purgeInterval)
+	}
+
 	log.Printf("start the server on port %d", *port)
 
-	laptopServer := service.NewLaptopServer(service.NewInMemoryLaptopStore(), service.NewDiscImageStore("img"), service.NewInMemoryRatingStore())
+	laptopStore, imageStore, ratingStore, err := newStores(*storeType, *dataFolder, *snapshotEvery)
+	if err != nil{
+		log.Fatal(err)
+	}
+
+	laptopServer := service.NewLaptopServer(laptopStore, imageStore, ratingStore)
+	if *purgeInterval > 0{
+		go laptopServer.RunPurge(context.Background(), *retention, *purgeInterval)
+	}
+
 	grpcServer := grpc.NewServer()
 
 	pb.RegisterLaptopServiceServer(grpcServer, laptopServer)
@@ -33,4 +62,59 @@ func main(){
 	if err != nil{
 		log.Fatal(err)
 	}
-}
\ No newline at end of file
+}
+
+func newStores(storeType string, dataFolder string, snapshotEvery int) (service.LaptopStore, service.ImageStore, service.RatingStore, error){
+	imageStore := service.NewDiscImageStore(imageFolder)
+
+	switch storeType{
+	case "memory":
+		return service.NewInMemoryLaptopStore(), imageStore, service.NewInMemoryRatingStore(), nil
+	case "file":
+		laptopStore, err := service.NewFileLaptopStore(filepath.Join(dataFolder, "laptop_files"))
+		return laptopStore, imageStore, service.NewInMemoryRatingStore(), err
+	case "wal":
+		laptopStore, err := service.NewWALLaptopStore(filepath.Join(dataFolder, "laptop_wal"), snapshotEvery)
+		if err != nil{
+			return nil, nil, nil, err
+		}
+		ratingStore, err := service.NewWALRatingStore(filepath.Join(dataFolder, "rating_wal"), snapshotEvery)
+		return laptopStore, imageStore, ratingStore, err
+	case "bolt":
+		err := os.MkdirAll(dataFolder, 0755)
+		if err != nil{
+			return nil, nil, nil, err
+		}
+		laptopStore, err := service.NewBoltLaptopStore(filepath.Join(dataFolder, "laptop.bolt"))
+		return laptopStore, imageStore, service.NewInMemoryRatingStore(), err
+	case "sql":
+		return newSQLStores(dataFolder)
+	default:
+		return nil, nil, nil, fmt.Errorf("unknown store %q", storeType)
+	}
+}
+
+func newSQLStores(dataFolder string) (service.LaptopStore, service.ImageStore, service.RatingStore, error){
+	err := os.MkdirAll(dataFolder, 0755)
+	if err != nil{
+		return nil, nil, nil, err
+	}
+
+	db, err := sql.Open("sqlite3", "file:"+filepath.Join(dataFolder, "laptop.db")+"?_foreign_keys=on&_busy_timeout=5000")
+	if err != nil{
+		return nil, nil, nil, err
+	}
+
+	laptopStore, err := service.NewSQLLaptopStore(db)
+	if err != nil{
+		return nil, nil, nil, err
+	}
+
+	imageStore, err := service.NewSQLImageStore(db, imageFolder)
+	if err != nil{
+		return nil, nil, nil, err
+	}
+
+	ratingStore, err := service.NewSQLRatingStore(db)
+	return laptopStore, imageStore, ratingStore, err
+}
@@ -20,6 +20,67 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type Predicate_Operator int32
+
+const (
+	Predicate_UNKNOWN          Predicate_Operator = 0
+	Predicate_EQUAL            Predicate_Operator = 1
+	Predicate_NOT_EQUAL        Predicate_Operator = 2
+	Predicate_LESS             Predicate_Operator = 3
+	Predicate_LESS_OR_EQUAL    Predicate_Operator = 4
+	Predicate_GREATER          Predicate_Operator = 5
+	Predicate_GREATER_OR_EQUAL Predicate_Operator = 6
+)
+
+// Enum value maps for Predicate_Operator.
+var (
+	Predicate_Operator_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "EQUAL",
+		2: "NOT_EQUAL",
+		3: "LESS",
+		4: "LESS_OR_EQUAL",
+		5: "GREATER",
+		6: "GREATER_OR_EQUAL",
+	}
+	Predicate_Operator_value = map[string]int32{
+		"UNKNOWN":          0,
+		"EQUAL":            1,
+		"NOT_EQUAL":        2,
+		"LESS":             3,
+		"LESS_OR_EQUAL":    4,
+		"GREATER":          5,
+		"GREATER_OR_EQUAL": 6,
+	}
+)
+
+func (x Predicate_Operator) Enum() *Predicate_Operator {
+	p := new(Predicate_Operator)
+	*p = x
+	return p
+}
+
+func (x Predicate_Operator) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Predicate_Operator) Descriptor() protoreflect.EnumDescriptor {
+	return file_filter_message_proto_enumTypes[0].Descriptor()
+}
+
+func (Predicate_Operator) Type() protoreflect.EnumType {
+	return &file_filter_message_proto_enumTypes[0]
+}
+
+func (x Predicate_Operator) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Predicate_Operator.Descriptor instead.
+func (Predicate_Operator) EnumDescriptor() ([]byte, []int) {
+	return file_filter_message_proto_rawDescGZIP(), []int{2, 0}
+}
+
 type Filter struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -29,6 +90,30 @@ type Filter struct {
 	MinCpuCores uint32  `protobuf:"varint,2,opt,name=min_cpu_cores,json=minCpuCores,proto3" json:"min_cpu_cores,omitempty"`
 	MinCpuGhz   float64 `protobuf:"fixed64,3,opt,name=min_cpu_ghz,json=minCpuGhz,proto3" json:"min_cpu_ghz,omitempty"`
 	MinRam      *Memory `protobuf:"bytes,4,opt,name=min_ram,json=minRam,proto3" json:"min_ram,omitempty"`
+	// laptop brand must be one of these, ignoring case
+	Brands []string `protobuf:"bytes,5,rep,name=brands,proto3" json:"brands,omitempty"`
+	// laptop name must be one of these, ignoring case
+	Names          []string `protobuf:"bytes,6,rep,name=names,proto3" json:"names,omitempty"`
+	MinPriceUsd    float64  `protobuf:"fixed64,7,opt,name=min_price_usd,json=minPriceUsd,proto3" json:"min_price_usd,omitempty"`
+	MinReleaseYear uint32   `protobuf:"varint,8,opt,name=min_release_year,json=minReleaseYear,proto3" json:"min_release_year,omitempty"`
+	MaxReleaseYear uint32   `protobuf:"varint,9,opt,name=max_release_year,json=maxReleaseYear,proto3" json:"max_release_year,omitempty"`
+	// at least one GPU must have this brand, ignoring case, and min_gpu_memory
+	GpuBrand     string  `protobuf:"bytes,10,opt,name=gpu_brand,json=gpuBrand,proto3" json:"gpu_brand,omitempty"`
+	MinGpuMemory *Memory `protobuf:"bytes,11,opt,name=min_gpu_memory,json=minGpuMemory,proto3" json:"min_gpu_memory,omitempty"`
+	// total capacity of all SSD and HDD storages
+	MinSsd              *Memory            `protobuf:"bytes,12,opt,name=min_ssd,json=minSsd,proto3" json:"min_ssd,omitempty"`
+	MinHdd              *Memory            `protobuf:"bytes,13,opt,name=min_hdd,json=minHdd,proto3" json:"min_hdd,omitempty"`
+	MinScreenSizeInch   float32            `protobuf:"fixed32,14,opt,name=min_screen_size_inch,json=minScreenSizeInch,proto3" json:"min_screen_size_inch,omitempty"`
+	MaxScreenSizeInch   float32            `protobuf:"fixed32,15,opt,name=max_screen_size_inch,json=maxScreenSizeInch,proto3" json:"max_screen_size_inch,omitempty"`
+	MinScreenResolution *Screen_Resolution `protobuf:"bytes,16,opt,name=min_screen_resolution,json=minScreenResolution,proto3" json:"min_screen_resolution,omitempty"`
+	ScreenPannel        Screen_Pannel      `protobuf:"varint,17,opt,name=screen_pannel,json=screenPannel,proto3,enum=Screen_Pannel" json:"screen_pannel,omitempty"`
+	RequireMultitouch   bool               `protobuf:"varint,18,opt,name=require_multitouch,json=requireMultitouch,proto3" json:"require_multitouch,omitempty"`
+	KeyboardLayout      Keyboard_Layout    `protobuf:"varint,19,opt,name=keyboard_layout,json=keyboardLayout,proto3,enum=Keyboard_Layout" json:"keyboard_layout,omitempty"`
+	RequireBacklit      bool               `protobuf:"varint,20,opt,name=require_backlit,json=requireBacklit,proto3" json:"require_backlit,omitempty"`
+	// Types that are assignable to MaxWeight:
+	//	*Filter_MaxWeightKg
+	//	*Filter_MaxWeightLb
+	MaxWeight isFilter_MaxWeight `protobuf_oneof:"max_weight"`
 }
 
 func (x *Filter) Reset() {
@@ -91,22 +176,559 @@ func (x *Filter) GetMinRam() *Memory {
 	return nil
 }
 
+func (x *Filter) GetBrands() []string {
+	if x != nil {
+		return x.Brands
+	}
+	return nil
+}
+
+func (x *Filter) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+func (x *Filter) GetMinPriceUsd() float64 {
+	if x != nil {
+		return x.MinPriceUsd
+	}
+	return 0
+}
+
+func (x *Filter) GetMinReleaseYear() uint32 {
+	if x != nil {
+		return x.MinReleaseYear
+	}
+	return 0
+}
+
+func (x *Filter) GetMaxReleaseYear() uint32 {
+	if x != nil {
+		return x.MaxReleaseYear
+	}
+	return 0
+}
+
+func (x *Filter) GetGpuBrand() string {
+	if x != nil {
+		return x.GpuBrand
+	}
+	return ""
+}
+
+func (x *Filter) GetMinGpuMemory() *Memory {
+	if x != nil {
+		return x.MinGpuMemory
+	}
+	return nil
+}
+
+func (x *Filter) GetMinSsd() *Memory {
+	if x != nil {
+		return x.MinSsd
+	}
+	return nil
+}
+
+func (x *Filter) GetMinHdd() *Memory {
+	if x != nil {
+		return x.MinHdd
+	}
+	return nil
+}
+
+func (x *Filter) GetMinScreenSizeInch() float32 {
+	if x != nil {
+		return x.MinScreenSizeInch
+	}
+	return 0
+}
+
+func (x *Filter) GetMaxScreenSizeInch() float32 {
+	if x != nil {
+		return x.MaxScreenSizeInch
+	}
+	return 0
+}
+
+func (x *Filter) GetMinScreenResolution() *Screen_Resolution {
+	if x != nil {
+		return x.MinScreenResolution
+	}
+	return nil
+}
+
+func (x *Filter) GetScreenPannel() Screen_Pannel {
+	if x != nil {
+		return x.ScreenPannel
+	}
+	return Screen_UNKNOWN
+}
+
+func (x *Filter) GetRequireMultitouch() bool {
+	if x != nil {
+		return x.RequireMultitouch
+	}
+	return false
+}
+
+func (x *Filter) GetKeyboardLayout() Keyboard_Layout {
+	if x != nil {
+		return x.KeyboardLayout
+	}
+	return Keyboard_UNKNOWN
+}
+
+func (x *Filter) GetRequireBacklit() bool {
+	if x != nil {
+		return x.RequireBacklit
+	}
+	return false
+}
+
+func (m *Filter) GetMaxWeight() isFilter_MaxWeight {
+	if m != nil {
+		return m.MaxWeight
+	}
+	return nil
+}
+
+func (x *Filter) GetMaxWeightKg() float64 {
+	if x, ok := x.GetMaxWeight().(*Filter_MaxWeightKg); ok {
+		return x.MaxWeightKg
+	}
+	return 0
+}
+
+func (x *Filter) GetMaxWeightLb() float64 {
+	if x, ok := x.GetMaxWeight().(*Filter_MaxWeightLb); ok {
+		return x.MaxWeightLb
+	}
+	return 0
+}
+
+type isFilter_MaxWeight interface {
+	isFilter_MaxWeight()
+}
+
+type Filter_MaxWeightKg struct {
+	MaxWeightKg float64 `protobuf:"fixed64,21,opt,name=max_weight_kg,json=maxWeightKg,proto3,oneof"`
+}
+
+type Filter_MaxWeightLb struct {
+	MaxWeightLb float64 `protobuf:"fixed64,22,opt,name=max_weight_lb,json=maxWeightLb,proto3,oneof"`
+}
+
+func (*Filter_MaxWeightKg) isFilter_MaxWeight() {}
+
+func (*Filter_MaxWeightLb) isFilter_MaxWeight() {}
+
+// FilterExpression combines predicates over laptop fields with and, or and not
+type FilterExpression struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Node:
+	//	*FilterExpression_And
+	//	*FilterExpression_Or
+	//	*FilterExpression_Not
+	//	*FilterExpression_Predicate
+	//	*FilterExpression_Filter
+	Node isFilterExpression_Node `protobuf_oneof:"node"`
+}
+
+func (x *FilterExpression) Reset() {
+	*x = FilterExpression{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filter_message_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FilterExpression) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilterExpression) ProtoMessage() {}
+
+func (x *FilterExpression) ProtoReflect() protoreflect.Message {
+	mi := &file_filter_message_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilterExpression.ProtoReflect.Descriptor instead.
+func (*FilterExpression) Descriptor() ([]byte, []int) {
+	return file_filter_message_proto_rawDescGZIP(), []int{1}
+}
+
+func (m *FilterExpression) GetNode() isFilterExpression_Node {
+	if m != nil {
+		return m.Node
+	}
+	return nil
+}
+
+func (x *FilterExpression) GetAnd() *FilterExpression_List {
+	if x, ok := x.GetNode().(*FilterExpression_And); ok {
+		return x.And
+	}
+	return nil
+}
+
+func (x *FilterExpression) GetOr() *FilterExpression_List {
+	if x, ok := x.GetNode().(*FilterExpression_Or); ok {
+		return x.Or
+	}
+	return nil
+}
+
+func (x *FilterExpression) GetNot() *FilterExpression {
+	if x, ok := x.GetNode().(*FilterExpression_Not); ok {
+		return x.Not
+	}
+	return nil
+}
+
+func (x *FilterExpression) GetPredicate() *Predicate {
+	if x, ok := x.GetNode().(*FilterExpression_Predicate); ok {
+		return x.Predicate
+	}
+	return nil
+}
+
+func (x *FilterExpression) GetFilter() *Filter {
+	if x, ok := x.GetNode().(*FilterExpression_Filter); ok {
+		return x.Filter
+	}
+	return nil
+}
+
+type isFilterExpression_Node interface {
+	isFilterExpression_Node()
+}
+
+type FilterExpression_And struct {
+	And *FilterExpression_List `protobuf:"bytes,1,opt,name=and,proto3,oneof"`
+}
+
+type FilterExpression_Or struct {
+	Or *FilterExpression_List `protobuf:"bytes,2,opt,name=or,proto3,oneof"`
+}
+
+type FilterExpression_Not struct {
+	Not *FilterExpression `protobuf:"bytes,3,opt,name=not,proto3,oneof"`
+}
+
+type FilterExpression_Predicate struct {
+	Predicate *Predicate `protobuf:"bytes,4,opt,name=predicate,proto3,oneof"`
+}
+
+type FilterExpression_Filter struct {
+	// matches when every criterion of the filter matches
+	Filter *Filter `protobuf:"bytes,5,opt,name=filter,proto3,oneof"`
+}
+
+func (*FilterExpression_And) isFilterExpression_Node() {}
+
+func (*FilterExpression_Or) isFilterExpression_Node() {}
+
+func (*FilterExpression_Not) isFilterExpression_Node() {}
+
+func (*FilterExpression_Predicate) isFilterExpression_Node() {}
+
+func (*FilterExpression_Filter) isFilterExpression_Node() {}
+
+// Predicate compares a laptop field with a value.
+// Repeated fields such as gpu.brand match when any element matches,
+// NOT_EQUAL matches when no element is equal.
+type Predicate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// path of the laptop field, e.g. brand, cpu.number_cores or gpu.memory
+	Field    string             `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Operator Predicate_Operator `protobuf:"varint,2,opt,name=operator,proto3,enum=Predicate_Operator" json:"operator,omitempty"`
+	// Types that are assignable to Value:
+	//	*Predicate_Text
+	//	*Predicate_Number
+	//	*Predicate_Flag
+	//	*Predicate_Memory
+	Value isPredicate_Value `protobuf_oneof:"value"`
+}
+
+func (x *Predicate) Reset() {
+	*x = Predicate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filter_message_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Predicate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Predicate) ProtoMessage() {}
+
+func (x *Predicate) ProtoReflect() protoreflect.Message {
+	mi := &file_filter_message_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Predicate.ProtoReflect.Descriptor instead.
+func (*Predicate) Descriptor() ([]byte, []int) {
+	return file_filter_message_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Predicate) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *Predicate) GetOperator() Predicate_Operator {
+	if x != nil {
+		return x.Operator
+	}
+	return Predicate_UNKNOWN
+}
+
+func (m *Predicate) GetValue() isPredicate_Value {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (x *Predicate) GetText() string {
+	if x, ok := x.GetValue().(*Predicate_Text); ok {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Predicate) GetNumber() float64 {
+	if x, ok := x.GetValue().(*Predicate_Number); ok {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *Predicate) GetFlag() bool {
+	if x, ok := x.GetValue().(*Predicate_Flag); ok {
+		return x.Flag
+	}
+	return false
+}
+
+func (x *Predicate) GetMemory() *Memory {
+	if x, ok := x.GetValue().(*Predicate_Memory); ok {
+		return x.Memory
+	}
+	return nil
+}
+
+type isPredicate_Value interface {
+	isPredicate_Value()
+}
+
+type Predicate_Text struct {
+	Text string `protobuf:"bytes,3,opt,name=text,proto3,oneof"`
+}
+
+type Predicate_Number struct {
+	Number float64 `protobuf:"fixed64,4,opt,name=number,proto3,oneof"`
+}
+
+type Predicate_Flag struct {
+	Flag bool `protobuf:"varint,5,opt,name=flag,proto3,oneof"`
+}
+
+type Predicate_Memory struct {
+	Memory *Memory `protobuf:"bytes,6,opt,name=memory,proto3,oneof"`
+}
+
+func (*Predicate_Text) isPredicate_Value() {}
+
+func (*Predicate_Number) isPredicate_Value() {}
+
+func (*Predicate_Flag) isPredicate_Value() {}
+
+func (*Predicate_Memory) isPredicate_Value() {}
+
+type FilterExpression_List struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Expressions []*FilterExpression `protobuf:"bytes,1,rep,name=expressions,proto3" json:"expressions,omitempty"`
+}
+
+func (x *FilterExpression_List) Reset() {
+	*x = FilterExpression_List{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filter_message_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FilterExpression_List) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilterExpression_List) ProtoMessage() {}
+
+func (x *FilterExpression_List) ProtoReflect() protoreflect.Message {
+	mi := &file_filter_message_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilterExpression_List.ProtoReflect.Descriptor instead.
+func (*FilterExpression_List) Descriptor() ([]byte, []int) {
+	return file_filter_message_proto_rawDescGZIP(), []int{1, 0}
+}
+
+func (x *FilterExpression_List) GetExpressions() []*FilterExpression {
+	if x != nil {
+		return x.Expressions
+	}
+	return nil
+}
+
 var File_filter_message_proto protoreflect.FileDescriptor
 
 var file_filter_message_proto_rawDesc = []byte{
 	0x0a, 0x14, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
 	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x14, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x6d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x92, 0x01, 0x0a,
-	0x06, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x22, 0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x5f, 0x70,
-	0x72, 0x69, 0x63, 0x65, 0x5f, 0x75, 0x73, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b,
-	0x6d, 0x61, 0x78, 0x50, 0x72, 0x69, 0x63, 0x65, 0x55, 0x73, 0x64, 0x12, 0x22, 0x0a, 0x0d, 0x6d,
-	0x69, 0x6e, 0x5f, 0x63, 0x70, 0x75, 0x5f, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x0b, 0x6d, 0x69, 0x6e, 0x43, 0x70, 0x75, 0x43, 0x6f, 0x72, 0x65, 0x73, 0x12,
-	0x1e, 0x0a, 0x0b, 0x6d, 0x69, 0x6e, 0x5f, 0x63, 0x70, 0x75, 0x5f, 0x67, 0x68, 0x7a, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6d, 0x69, 0x6e, 0x43, 0x70, 0x75, 0x47, 0x68, 0x7a, 0x12,
-	0x20, 0x0a, 0x07, 0x6d, 0x69, 0x6e, 0x5f, 0x72, 0x61, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x07, 0x2e, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x52, 0x06, 0x6d, 0x69, 0x6e, 0x52, 0x61,
-	0x6d, 0x42, 0x06, 0x5a, 0x04, 0x2e, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x14, 0x73, 0x63,
+	0x72, 0x65, 0x65, 0x6e, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x1a, 0x16, 0x6b, 0x65, 0x79, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x5f, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x94, 0x07, 0x0a, 0x06, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x22, 0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x5f, 0x70, 0x72, 0x69,
+	0x63, 0x65, 0x5f, 0x75, 0x73, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x6d, 0x61,
+	0x78, 0x50, 0x72, 0x69, 0x63, 0x65, 0x55, 0x73, 0x64, 0x12, 0x22, 0x0a, 0x0d, 0x6d, 0x69, 0x6e,
+	0x5f, 0x63, 0x70, 0x75, 0x5f, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x0b, 0x6d, 0x69, 0x6e, 0x43, 0x70, 0x75, 0x43, 0x6f, 0x72, 0x65, 0x73, 0x12, 0x1e, 0x0a,
+	0x0b, 0x6d, 0x69, 0x6e, 0x5f, 0x63, 0x70, 0x75, 0x5f, 0x67, 0x68, 0x7a, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x09, 0x6d, 0x69, 0x6e, 0x43, 0x70, 0x75, 0x47, 0x68, 0x7a, 0x12, 0x20, 0x0a,
+	0x07, 0x6d, 0x69, 0x6e, 0x5f, 0x72, 0x61, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x07,
+	0x2e, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x52, 0x06, 0x6d, 0x69, 0x6e, 0x52, 0x61, 0x6d, 0x12,
+	0x16, 0x0a, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x64, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x06, 0x62, 0x72, 0x61, 0x6e, 0x64, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73,
+	0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x22, 0x0a,
+	0x0d, 0x6d, 0x69, 0x6e, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x75, 0x73, 0x64, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x6d, 0x69, 0x6e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x55, 0x73,
+	0x64, 0x12, 0x28, 0x0a, 0x10, 0x6d, 0x69, 0x6e, 0x5f, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65,
+	0x5f, 0x79, 0x65, 0x61, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x6d, 0x69, 0x6e,
+	0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x59, 0x65, 0x61, 0x72, 0x12, 0x28, 0x0a, 0x10, 0x6d,
+	0x61, 0x78, 0x5f, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x5f, 0x79, 0x65, 0x61, 0x72, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73,
+	0x65, 0x59, 0x65, 0x61, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x70, 0x75, 0x5f, 0x62, 0x72, 0x61,
+	0x6e, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x67, 0x70, 0x75, 0x42, 0x72, 0x61,
+	0x6e, 0x64, 0x12, 0x2d, 0x0a, 0x0e, 0x6d, 0x69, 0x6e, 0x5f, 0x67, 0x70, 0x75, 0x5f, 0x6d, 0x65,
+	0x6d, 0x6f, 0x72, 0x79, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x07, 0x2e, 0x4d, 0x65, 0x6d,
+	0x6f, 0x72, 0x79, 0x52, 0x0c, 0x6d, 0x69, 0x6e, 0x47, 0x70, 0x75, 0x4d, 0x65, 0x6d, 0x6f, 0x72,
+	0x79, 0x12, 0x20, 0x0a, 0x07, 0x6d, 0x69, 0x6e, 0x5f, 0x73, 0x73, 0x64, 0x18, 0x0c, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x07, 0x2e, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x52, 0x06, 0x6d, 0x69, 0x6e,
+	0x53, 0x73, 0x64, 0x12, 0x20, 0x0a, 0x07, 0x6d, 0x69, 0x6e, 0x5f, 0x68, 0x64, 0x64, 0x18, 0x0d,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x07, 0x2e, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x52, 0x06, 0x6d,
+	0x69, 0x6e, 0x48, 0x64, 0x64, 0x12, 0x2f, 0x0a, 0x14, 0x6d, 0x69, 0x6e, 0x5f, 0x73, 0x63, 0x72,
+	0x65, 0x65, 0x6e, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x69, 0x6e, 0x63, 0x68, 0x18, 0x0e, 0x20,
+	0x01, 0x28, 0x02, 0x52, 0x11, 0x6d, 0x69, 0x6e, 0x53, 0x63, 0x72, 0x65, 0x65, 0x6e, 0x53, 0x69,
+	0x7a, 0x65, 0x49, 0x6e, 0x63, 0x68, 0x12, 0x2f, 0x0a, 0x14, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x63,
+	0x72, 0x65, 0x65, 0x6e, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x69, 0x6e, 0x63, 0x68, 0x18, 0x0f,
+	0x20, 0x01, 0x28, 0x02, 0x52, 0x11, 0x6d, 0x61, 0x78, 0x53, 0x63, 0x72, 0x65, 0x65, 0x6e, 0x53,
+	0x69, 0x7a, 0x65, 0x49, 0x6e, 0x63, 0x68, 0x12, 0x46, 0x0a, 0x15, 0x6d, 0x69, 0x6e, 0x5f, 0x73,
+	0x63, 0x72, 0x65, 0x65, 0x6e, 0x5f, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x53, 0x63, 0x72, 0x65, 0x65, 0x6e, 0x2e,
+	0x52, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x13, 0x6d, 0x69, 0x6e, 0x53,
+	0x63, 0x72, 0x65, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x33, 0x0a, 0x0d, 0x73, 0x63, 0x72, 0x65, 0x65, 0x6e, 0x5f, 0x70, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
+	0x18, 0x11, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x53, 0x63, 0x72, 0x65, 0x65, 0x6e, 0x2e,
+	0x50, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x52, 0x0c, 0x73, 0x63, 0x72, 0x65, 0x65, 0x6e, 0x50, 0x61,
+	0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x2d, 0x0a, 0x12, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x5f,
+	0x6d, 0x75, 0x6c, 0x74, 0x69, 0x74, 0x6f, 0x75, 0x63, 0x68, 0x18, 0x12, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x11, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x74, 0x6f,
+	0x75, 0x63, 0x68, 0x12, 0x39, 0x0a, 0x0f, 0x6b, 0x65, 0x79, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x5f,
+	0x6c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x4b,
+	0x65, 0x79, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x2e, 0x4c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x52, 0x0e,
+	0x6b, 0x65, 0x79, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x4c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x12, 0x27,
+	0x0a, 0x0f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x5f, 0x62, 0x61, 0x63, 0x6b, 0x6c, 0x69,
+	0x74, 0x18, 0x14, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65,
+	0x42, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x5f, 0x77,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x6b, 0x67, 0x18, 0x15, 0x20, 0x01, 0x28, 0x01, 0x48, 0x00,
+	0x52, 0x0b, 0x6d, 0x61, 0x78, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x4b, 0x67, 0x12, 0x24, 0x0a,
+	0x0d, 0x6d, 0x61, 0x78, 0x5f, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x6c, 0x62, 0x18, 0x16,
+	0x20, 0x01, 0x28, 0x01, 0x48, 0x00, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x57, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x4c, 0x62, 0x42, 0x0c, 0x0a, 0x0a, 0x6d, 0x61, 0x78, 0x5f, 0x77, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x22, 0xa3, 0x02, 0x0a, 0x10, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x45, 0x78, 0x70, 0x72,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2a, 0x0a, 0x03, 0x61, 0x6e, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x45, 0x78, 0x70, 0x72,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x48, 0x00, 0x52, 0x03, 0x61,
+	0x6e, 0x64, 0x12, 0x28, 0x0a, 0x02, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x48, 0x00, 0x52, 0x02, 0x6f, 0x72, 0x12, 0x25, 0x0a, 0x03,
+	0x6e, 0x6f, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x46, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x03,
+	0x6e, 0x6f, 0x74, 0x12, 0x2a, 0x0a, 0x09, 0x70, 0x72, 0x65, 0x64, 0x69, 0x63, 0x61, 0x74, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x50, 0x72, 0x65, 0x64, 0x69, 0x63, 0x61,
+	0x74, 0x65, 0x48, 0x00, 0x52, 0x09, 0x70, 0x72, 0x65, 0x64, 0x69, 0x63, 0x61, 0x74, 0x65, 0x12,
+	0x21, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x07, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x48, 0x00, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x1a, 0x3b, 0x0a, 0x04, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x0b, 0x65, 0x78,
+	0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x11, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x52, 0x0b, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x42,
+	0x06, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x22, 0xb7, 0x02, 0x0a, 0x09, 0x50, 0x72, 0x65, 0x64,
+	0x69, 0x63, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x2f, 0x0a, 0x08, 0x6f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e,
+	0x50, 0x72, 0x65, 0x64, 0x69, 0x63, 0x61, 0x74, 0x65, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x6f, 0x72, 0x52, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x04,
+	0x74, 0x65, 0x78, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x04, 0x74, 0x65,
+	0x78, 0x74, 0x12, 0x18, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x01, 0x48, 0x00, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x04,
+	0x66, 0x6c, 0x61, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x04, 0x66, 0x6c,
+	0x61, 0x67, 0x12, 0x21, 0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x07, 0x2e, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x48, 0x00, 0x52, 0x06, 0x6d,
+	0x65, 0x6d, 0x6f, 0x72, 0x79, 0x22, 0x71, 0x0a, 0x08, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f,
+	0x72, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x09,
+	0x0a, 0x05, 0x45, 0x51, 0x55, 0x41, 0x4c, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x4e, 0x4f, 0x54,
+	0x5f, 0x45, 0x51, 0x55, 0x41, 0x4c, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x4c, 0x45, 0x53, 0x53,
+	0x10, 0x03, 0x12, 0x11, 0x0a, 0x0d, 0x4c, 0x45, 0x53, 0x53, 0x5f, 0x4f, 0x52, 0x5f, 0x45, 0x51,
+	0x55, 0x41, 0x4c, 0x10, 0x04, 0x12, 0x0b, 0x0a, 0x07, 0x47, 0x52, 0x45, 0x41, 0x54, 0x45, 0x52,
+	0x10, 0x05, 0x12, 0x14, 0x0a, 0x10, 0x47, 0x52, 0x45, 0x41, 0x54, 0x45, 0x52, 0x5f, 0x4f, 0x52,
+	0x5f, 0x45, 0x51, 0x55, 0x41, 0x4c, 0x10, 0x06, 0x42, 0x07, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x42, 0x06, 0x5a, 0x04, 0x2e, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
 	0x33,
 }
 
@@ -122,18 +744,40 @@ func file_filter_message_proto_rawDescGZIP() []byte {
 	return file_filter_message_proto_rawDescData
 }
 
-var file_filter_message_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_filter_message_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_filter_message_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_filter_message_proto_goTypes = []interface{}{
-	(*Filter)(nil), // 0: Filter
-	(*Memory)(nil), // 1: Memory
+	(Predicate_Operator)(0),       // 0: Predicate.Operator
+	(*Filter)(nil),                // 1: Filter
+	(*FilterExpression)(nil),      // 2: FilterExpression
+	(*Predicate)(nil),             // 3: Predicate
+	(*FilterExpression_List)(nil), // 4: FilterExpression.List
+	(*Memory)(nil),                // 5: Memory
+	(*Screen_Resolution)(nil),     // 6: Screen.Resolution
+	(Screen_Pannel)(0),            // 7: Screen.Pannel
+	(Keyboard_Layout)(0),          // 8: Keyboard.Layout
 }
 var file_filter_message_proto_depIdxs = []int32{
-	1, // 0: Filter.min_ram:type_name -> Memory
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	5,  // 0: Filter.min_ram:type_name -> Memory
+	5,  // 1: Filter.min_gpu_memory:type_name -> Memory
+	5,  // 2: Filter.min_ssd:type_name -> Memory
+	5,  // 3: Filter.min_hdd:type_name -> Memory
+	6,  // 4: Filter.min_screen_resolution:type_name -> Screen.Resolution
+	7,  // 5: Filter.screen_pannel:type_name -> Screen.Pannel
+	8,  // 6: Filter.keyboard_layout:type_name -> Keyboard.Layout
+	4,  // 7: FilterExpression.and:type_name -> FilterExpression.List
+	4,  // 8: FilterExpression.or:type_name -> FilterExpression.List
+	2,  // 9: FilterExpression.not:type_name -> FilterExpression
+	3,  // 10: FilterExpression.predicate:type_name -> Predicate
+	1,  // 11: FilterExpression.filter:type_name -> Filter
+	0,  // 12: Predicate.operator:type_name -> Predicate.Operator
+	5,  // 13: Predicate.memory:type_name -> Memory
+	2,  // 14: FilterExpression.List.expressions:type_name -> FilterExpression
+	15, // [15:15] is the sub-list for method output_type
+	15, // [15:15] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_filter_message_proto_init() }
@@ -142,6 +786,8 @@ func file_filter_message_proto_init() {
 		return
 	}
 	file_memory_message_proto_init()
+	file_screen_message_proto_init()
+	file_keyboard_message_proto_init()
 	if !protoimpl.UnsafeEnabled {
 		file_filter_message_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Filter); i {
@@ -155,19 +801,73 @@ func file_filter_message_proto_init() {
 				return nil
 			}
 		}
+		file_filter_message_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FilterExpression); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filter_message_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Predicate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filter_message_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FilterExpression_List); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_filter_message_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*Filter_MaxWeightKg)(nil),
+		(*Filter_MaxWeightLb)(nil),
+	}
+	file_filter_message_proto_msgTypes[1].OneofWrappers = []interface{}{
+		(*FilterExpression_And)(nil),
+		(*FilterExpression_Or)(nil),
+		(*FilterExpression_Not)(nil),
+		(*FilterExpression_Predicate)(nil),
+		(*FilterExpression_Filter)(nil),
+	}
+	file_filter_message_proto_msgTypes[2].OneofWrappers = []interface{}{
+		(*Predicate_Text)(nil),
+		(*Predicate_Number)(nil),
+		(*Predicate_Flag)(nil),
+		(*Predicate_Memory)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_filter_message_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   1,
+			NumEnums:      1,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_filter_message_proto_goTypes,
 		DependencyIndexes: file_filter_message_proto_depIdxs,
+		EnumInfos:         file_filter_message_proto_enumTypes,
 		MessageInfos:      file_filter_message_proto_msgTypes,
 	}.Build()
 	File_filter_message_proto = out.File
@@ -0,0 +1,310 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.26.0
+// 	protoc        v3.19.3
+// source: store_record_message.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// StoreRecord is an entry of the write-ahead log or of a snapshot of a store.
+// Records hold the stored state of an item, so replaying a record twice is harmless.
+type StoreRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Record:
+	//	*StoreRecord_Laptop
+	//	*StoreRecord_RemovedLaptopId
+	//	*StoreRecord_Rating
+	//	*StoreRecord_RemovedRatingId
+	Record isStoreRecord_Record `protobuf_oneof:"record"`
+}
+
+func (x *StoreRecord) Reset() {
+	*x = StoreRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_store_record_message_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StoreRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoreRecord) ProtoMessage() {}
+
+func (x *StoreRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_store_record_message_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoreRecord.ProtoReflect.Descriptor instead.
+func (*StoreRecord) Descriptor() ([]byte, []int) {
+	return file_store_record_message_proto_rawDescGZIP(), []int{0}
+}
+
+func (m *StoreRecord) GetRecord() isStoreRecord_Record {
+	if m != nil {
+		return m.Record
+	}
+	return nil
+}
+
+func (x *StoreRecord) GetLaptop() *Laptop {
+	if x, ok := x.GetRecord().(*StoreRecord_Laptop); ok {
+		return x.Laptop
+	}
+	return nil
+}
+
+func (x *StoreRecord) GetRemovedLaptopId() string {
+	if x, ok := x.GetRecord().(*StoreRecord_RemovedLaptopId); ok {
+		return x.RemovedLaptopId
+	}
+	return ""
+}
+
+func (x *StoreRecord) GetRating() *RatingRecord {
+	if x, ok := x.GetRecord().(*StoreRecord_Rating); ok {
+		return x.Rating
+	}
+	return nil
+}
+
+func (x *StoreRecord) GetRemovedRatingId() string {
+	if x, ok := x.GetRecord().(*StoreRecord_RemovedRatingId); ok {
+		return x.RemovedRatingId
+	}
+	return ""
+}
+
+type isStoreRecord_Record interface {
+	isStoreRecord_Record()
+}
+
+type StoreRecord_Laptop struct {
+	// the stored version of a laptop
+	Laptop *Laptop `protobuf:"bytes,1,opt,name=laptop,proto3,oneof"`
+}
+
+type StoreRecord_RemovedLaptopId struct {
+	// a laptop that was removed from the store
+	RemovedLaptopId string `protobuf:"bytes,2,opt,name=removed_laptop_id,json=removedLaptopId,proto3,oneof"`
+}
+
+type StoreRecord_Rating struct {
+	Rating *RatingRecord `protobuf:"bytes,3,opt,name=rating,proto3,oneof"`
+}
+
+type StoreRecord_RemovedRatingId struct {
+	// the rating of a laptop that was removed from the store
+	RemovedRatingId string `protobuf:"bytes,4,opt,name=removed_rating_id,json=removedRatingId,proto3,oneof"`
+}
+
+func (*StoreRecord_Laptop) isStoreRecord_Record() {}
+
+func (*StoreRecord_RemovedLaptopId) isStoreRecord_Record() {}
+
+func (*StoreRecord_Rating) isStoreRecord_Record() {}
+
+func (*StoreRecord_RemovedRatingId) isStoreRecord_Record() {}
+
+type RatingRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LaptopId string  `protobuf:"bytes,1,opt,name=laptop_id,json=laptopId,proto3" json:"laptop_id,omitempty"`
+	Count    uint32  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Sum      float64 `protobuf:"fixed64,3,opt,name=sum,proto3" json:"sum,omitempty"`
+}
+
+func (x *RatingRecord) Reset() {
+	*x = RatingRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_store_record_message_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RatingRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RatingRecord) ProtoMessage() {}
+
+func (x *RatingRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_store_record_message_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RatingRecord.ProtoReflect.Descriptor instead.
+func (*RatingRecord) Descriptor() ([]byte, []int) {
+	return file_store_record_message_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RatingRecord) GetLaptopId() string {
+	if x != nil {
+		return x.LaptopId
+	}
+	return ""
+}
+
+func (x *RatingRecord) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *RatingRecord) GetSum() float64 {
+	if x != nil {
+		return x.Sum
+	}
+	return 0
+}
+
+var File_store_record_message_proto protoreflect.FileDescriptor
+
+var file_store_record_message_proto_rawDesc = []byte{
+	0x0a, 0x1a, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x5f, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x5f, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x14, 0x6c, 0x61,
+	0x70, 0x74, 0x6f, 0x70, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x22, 0xbf, 0x01, 0x0a, 0x0b, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x12, 0x21, 0x0a, 0x06, 0x6c, 0x61, 0x70, 0x74, 0x6f, 0x70, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x07, 0x2e, 0x4c, 0x61, 0x70, 0x74, 0x6f, 0x70, 0x48, 0x00, 0x52, 0x06, 0x6c,
+	0x61, 0x70, 0x74, 0x6f, 0x70, 0x12, 0x2c, 0x0a, 0x11, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64,
+	0x5f, 0x6c, 0x61, 0x70, 0x74, 0x6f, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x00, 0x52, 0x0f, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x4c, 0x61, 0x70, 0x74, 0x6f,
+	0x70, 0x49, 0x64, 0x12, 0x27, 0x0a, 0x06, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x48, 0x00, 0x52, 0x06, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x2c, 0x0a, 0x11,
+	0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x5f, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x69,
+	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0f, 0x72, 0x65, 0x6d, 0x6f, 0x76,
+	0x65, 0x64, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x49, 0x64, 0x42, 0x08, 0x0a, 0x06, 0x72, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x22, 0x53, 0x0a, 0x0c, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x61, 0x70, 0x74, 0x6f, 0x70, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x70, 0x74, 0x6f, 0x70, 0x49,
+	0x64, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x75, 0x6d, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x73, 0x75, 0x6d, 0x42, 0x06, 0x5a, 0x04, 0x2e, 0x2f, 0x70,
+	0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_store_record_message_proto_rawDescOnce sync.Once
+	file_store_record_message_proto_rawDescData = file_store_record_message_proto_rawDesc
+)
+
+func file_store_record_message_proto_rawDescGZIP() []byte {
+	file_store_record_message_proto_rawDescOnce.Do(func() {
+		file_store_record_message_proto_rawDescData = protoimpl.X.CompressGZIP(file_store_record_message_proto_rawDescData)
+	})
+	return file_store_record_message_proto_rawDescData
+}
+
+var file_store_record_message_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_store_record_message_proto_goTypes = []interface{}{
+	(*StoreRecord)(nil),  // 0: StoreRecord
+	(*RatingRecord)(nil), // 1: RatingRecord
+	(*Laptop)(nil),       // 2: Laptop
+}
+var file_store_record_message_proto_depIdxs = []int32{
+	2, // 0: StoreRecord.laptop:type_name -> Laptop
+	1, // 1: StoreRecord.rating:type_name -> RatingRecord
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_store_record_message_proto_init() }
+func file_store_record_message_proto_init() {
+	if File_store_record_message_proto != nil {
+		return
+	}
+	file_laptop_message_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_store_record_message_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StoreRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_store_record_message_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RatingRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_store_record_message_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*StoreRecord_Laptop)(nil),
+		(*StoreRecord_RemovedLaptopId)(nil),
+		(*StoreRecord_Rating)(nil),
+		(*StoreRecord_RemovedRatingId)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_store_record_message_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_store_record_message_proto_goTypes,
+		DependencyIndexes: file_store_record_message_proto_depIdxs,
+		MessageInfos:      file_store_record_message_proto_msgTypes,
+	}.Build()
+	File_store_record_message_proto = out.File
+	file_store_record_message_proto_rawDesc = nil
+	file_store_record_message_proto_goTypes = nil
+	file_store_record_message_proto_depIdxs = nil
+}
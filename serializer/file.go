@@ -3,6 +3,8 @@ package serializer
 import (
 	"fmt"
 	"io/ioutil"
+	"os"
+	"path/filepath"
 
 	"github.com/golang/protobuf/proto"
 )
@@ -35,6 +37,68 @@ func WriteProtobufToBinaryFile(message proto.Message, filename string) error{
 	return nil
 }
 
+// TempFileExtension is the extension of the temporary files written by WriteFileAtomic
+const TempFileExtension = ".tmp"
+
+// WriteProtobufToBinaryFileAtomic writes the message like WriteProtobufToBinaryFile,
+// but through WriteFileAtomic so that a crash leaves either the old or the new file
+func WriteProtobufToBinaryFileAtomic(message proto.Message, filename string) error{
+	data, err := proto.Marshal(message)
+	if (err != nil){
+		return fmt.Errorf("%w", err)
+	}
+
+	return WriteFileAtomic(filename, data)
+}
+
+// WriteFileAtomic writes the data to a temporary file and syncs it, then renames
+// it over filename and syncs the folder. A crash leaves either the old or the new
+// file, and possibly the temporary file, which the reader should remove.
+func WriteFileAtomic(filename string, data []byte) error{
+	tempFilename := filename + TempFileExtension
+	file, err := os.Create(tempFilename)
+	if err != nil{
+		return fmt.Errorf("%w", err)
+	}
+
+	_, err = file.Write(data)
+	if err == nil{
+		err = file.Sync()
+	}
+	if err != nil{
+		file.Close()
+		return fmt.Errorf("%w", err)
+	}
+
+	err = file.Close()
+	if err != nil{
+		return fmt.Errorf("%w", err)
+	}
+
+	err = os.Rename(tempFilename, filename)
+	if err != nil{
+		return fmt.Errorf("%w", err)
+	}
+
+	return SyncFolder(filepath.Dir(filename))
+}
+
+// SyncFolder makes the renames and removals in the folder durable
+func SyncFolder(folder string) error{
+	dir, err := os.Open(folder)
+	if err != nil{
+		return fmt.Errorf("%w", err)
+	}
+	defer dir.Close()
+
+	err = dir.Sync()
+	if err != nil{
+		return fmt.Errorf("%w", err)
+	}
+
+	return nil
+}
+
 func ReadProtobufFromBinaryFile(filename string, message proto.Message) error{
 	data, err := ioutil.ReadFile(filename)
 	if err != nil {
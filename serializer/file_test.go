@@ -4,6 +4,8 @@ import (
 	"gRPC/pb"
 	"gRPC/sample"
 	"gRPC/serializer"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/golang/protobuf/proto"
@@ -29,4 +31,27 @@ func TestFileSerializer(t *testing.T){
 
 	err = serializer.WriteProtobufToJSON(laptop2, jsonFile)
 	require.NoError(t, err)
-}
\ No newline at end of file
+}
+
+func TestWriteProtobufToBinaryFileAtomic(t *testing.T){
+	t.Parallel()
+
+	folder := t.TempDir()
+	binaryFile := filepath.Join(folder, "laptop.bin")
+
+	for i := 0; i < 2; i++{
+		laptop1 := sample.NewLaptop()
+		err := serializer.WriteProtobufToBinaryFileAtomic(laptop1, binaryFile)
+		require.NoError(t, err)
+
+		laptop2 := &pb.Laptop{}
+		err = serializer.ReadProtobufFromBinaryFile(binaryFile, laptop2)
+		require.NoError(t, err)
+		require.True(t, proto.Equal(laptop1, laptop2))
+	}
+
+	// only the file is left, the temporary file was renamed over it
+	entries, err := os.ReadDir(folder)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+}
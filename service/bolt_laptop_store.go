@@ -0,0 +1,418 @@
+package service
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"gRPC/pb"
+	"math"
+	"sort"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	// laptopsBucket maps the IDs to the laptops in binary protobuf
+	laptopsBucket = []byte("laptops")
+	// sequencesBucket maps the IDs to the creation sequences
+	sequencesBucket = []byte("sequences")
+	// createdBucket maps the creation sequences to the IDs, in the order laptops were created
+	createdBucket = []byte("created")
+	// priceIndexBucket maps the prices followed by the creation sequences to the IDs
+	priceIndexBucket = []byte("price_index")
+	// releaseYearIndexBucket maps the release years followed by the creation sequences to the IDs
+	releaseYearIndexBucket = []byte("release_year_index")
+	// metaBucket holds the revision of the store
+	metaBucket = []byte("meta")
+
+	revisionKey = []byte("revision")
+)
+
+// BoltLaptopStore is a LaptopStore backed by an embedded bbolt key-value
+// file. Laptops are kept in binary protobuf by ID, together with index
+// buckets on the price and the release year that searches scan by range.
+// Reads run concurrently in read-only transactions while writes are serialized.
+type BoltLaptopStore struct {
+	*txLaptopStore
+	db *bolt.DB
+}
+
+// NewBoltLaptopStore opens the store at the path, creating the file if needed
+func NewBoltLaptopStore(path string) (*BoltLaptopStore, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("cannot open bolt file: %w", err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{laptopsBucket, sequencesBucket, createdBucket, priceIndexBucket, releaseYearIndexBucket, metaBucket} {
+			_, err := tx.CreateBucketIfNotExists(name)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("cannot create buckets: %w", err)
+	}
+
+	store := &BoltLaptopStore{db: db}
+	store.txLaptopStore, err = newTxLaptopStore(store.transact)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return store, nil
+}
+
+// Close closes the file of the store
+func (store *BoltLaptopStore) Close() error {
+	return store.db.Close()
+}
+
+// transact runs the function in a read-write bolt transaction
+func (store *BoltLaptopStore) transact(transaction func(tx laptopTx) error) error {
+	return store.db.Update(func(tx *bolt.Tx) error {
+		return transaction(boltLaptopTx{tx})
+	})
+}
+
+func encodeUint64(value uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, value)
+	return key
+}
+
+// encodeFloat64 encodes the value so that encoded values sort in the order of the values
+func encodeFloat64(value float64) []byte {
+	bits := math.Float64bits(value)
+	if bits&(1<<63) != 0 {
+		bits = ^bits
+	} else {
+		bits |= 1 << 63
+	}
+	return encodeUint64(bits)
+}
+
+func priceIndexKey(laptop *pb.Laptop, sequence uint64) []byte {
+	return append(encodeFloat64(laptop.GetPriceUsd()), encodeUint64(sequence)...)
+}
+
+func releaseYearIndexKey(laptop *pb.Laptop, sequence uint64) []byte {
+	key := make([]byte, 4, 12)
+	binary.BigEndian.PutUint32(key, laptop.GetReleaseYear())
+	return append(key, encodeUint64(sequence)...)
+}
+
+// boltLaptopTx is a laptopTx in a read-write bolt transaction
+type boltLaptopTx struct {
+	tx *bolt.Tx
+}
+
+func (tx boltLaptopTx) get(ID string) (*pb.Laptop, error) {
+	return getBoltLaptop(tx.tx, []byte(ID))
+}
+
+// getBoltLaptop returns the stored laptop, deleted or not, or nil if there is none
+func getBoltLaptop(tx *bolt.Tx, ID []byte) (*pb.Laptop, error) {
+	data := tx.Bucket(laptopsBucket).Get(ID)
+	if data == nil {
+		return nil, nil
+	}
+
+	return unmarshalLaptop(data)
+}
+
+func (tx boltLaptopTx) nextRevision() (uint64, error) {
+	meta := tx.tx.Bucket(metaBucket)
+	revision := uint64(1)
+	if data := meta.Get(revisionKey); data != nil {
+		revision = binary.BigEndian.Uint64(data) + 1
+	}
+
+	err := meta.Put(revisionKey, encodeUint64(revision))
+	if err != nil {
+		return 0, fmt.Errorf("cannot write revision: %w", err)
+	}
+
+	return revision, nil
+}
+
+// put writes the laptop and its index entries
+func (tx boltLaptopTx) put(laptop *pb.Laptop, sequence uint64) error {
+	data, err := proto.Marshal(laptop)
+	if err != nil {
+		return fmt.Errorf("cannot encode laptop: %w", err)
+	}
+
+	ID := []byte(laptop.GetId())
+	err = tx.tx.Bucket(laptopsBucket).Put(ID, data)
+	if err == nil {
+		err = tx.tx.Bucket(priceIndexBucket).Put(priceIndexKey(laptop, sequence), ID)
+	}
+	if err == nil {
+		err = tx.tx.Bucket(releaseYearIndexBucket).Put(releaseYearIndexKey(laptop, sequence), ID)
+	}
+	if err != nil {
+		return fmt.Errorf("cannot write laptop: %w", err)
+	}
+
+	return nil
+}
+
+// unindex removes the index entries of the laptop
+func (tx boltLaptopTx) unindex(laptop *pb.Laptop, sequence uint64) error {
+	err := tx.tx.Bucket(priceIndexBucket).Delete(priceIndexKey(laptop, sequence))
+	if err == nil {
+		err = tx.tx.Bucket(releaseYearIndexBucket).Delete(releaseYearIndexKey(laptop, sequence))
+	}
+	if err != nil {
+		return fmt.Errorf("cannot remove index entries: %w", err)
+	}
+
+	return nil
+}
+
+func (tx boltLaptopTx) sequence(ID string) (uint64, error) {
+	data := tx.tx.Bucket(sequencesBucket).Get([]byte(ID))
+	if data == nil {
+		return 0, fmt.Errorf("cannot find sequence of laptop %s", ID)
+	}
+
+	return binary.BigEndian.Uint64(data), nil
+}
+
+func (tx boltLaptopTx) insert(laptop *pb.Laptop) error {
+	created := tx.tx.Bucket(createdBucket)
+	sequence, err := created.NextSequence()
+	if err != nil {
+		return fmt.Errorf("cannot get sequence: %w", err)
+	}
+
+	ID := []byte(laptop.GetId())
+	err = created.Put(encodeUint64(sequence), ID)
+	if err == nil {
+		err = tx.tx.Bucket(sequencesBucket).Put(ID, encodeUint64(sequence))
+	}
+	if err != nil {
+		return fmt.Errorf("cannot write sequence: %w", err)
+	}
+
+	return tx.put(laptop, sequence)
+}
+
+func (tx boltLaptopTx) update(old *pb.Laptop, laptop *pb.Laptop) error {
+	sequence, err := tx.sequence(laptop.GetId())
+	if err != nil {
+		return err
+	}
+
+	err = tx.unindex(old, sequence)
+	if err != nil {
+		return err
+	}
+
+	return tx.put(laptop, sequence)
+}
+
+func (tx boltLaptopTx) remove(laptop *pb.Laptop) error {
+	sequence, err := tx.sequence(laptop.GetId())
+	if err != nil {
+		return err
+	}
+
+	err = tx.unindex(laptop, sequence)
+	if err != nil {
+		return err
+	}
+
+	ID := []byte(laptop.GetId())
+	err = tx.tx.Bucket(laptopsBucket).Delete(ID)
+	if err == nil {
+		err = tx.tx.Bucket(sequencesBucket).Delete(ID)
+	}
+	if err == nil {
+		err = tx.tx.Bucket(createdBucket).Delete(encodeUint64(sequence))
+	}
+	if err != nil {
+		return fmt.Errorf("cannot delete laptop: %w", err)
+	}
+
+	return nil
+}
+
+func (tx boltLaptopTx) deletedBefore(deletedBefore time.Time) ([]*pb.Laptop, error) {
+	laptops := []*pb.Laptop{}
+	err := tx.tx.Bucket(createdBucket).ForEach(func(_, ID []byte) error {
+		laptop, err := getBoltLaptop(tx.tx, ID)
+		if err != nil {
+			return err
+		}
+
+		if laptop.GetDeletedAt() != nil && laptop.GetDeletedAt().AsTime().Before(deletedBefore) {
+			laptops = append(laptops, laptop)
+		}
+		return nil
+	})
+
+	return laptops, err
+}
+
+func (tx boltLaptopTx) all() ([]*pb.Laptop, error) {
+	laptops, _, err := selectBoltLaptops(tx.tx, nil, true, 0)
+	return laptops, err
+}
+
+func (store *BoltLaptopStore) Find(ID string) (*pb.Laptop, error) {
+	var laptop *pb.Laptop
+	err := store.db.View(func(tx *bolt.Tx) error {
+		var err error
+		laptop, err = getBoltLaptop(tx, []byte(ID))
+		return err
+	})
+	if err != nil || laptop.GetDeletedAt() != nil {
+		return nil, err
+	}
+
+	return laptop, nil
+}
+
+// indexedLaptop is an entry of an index bucket
+type indexedLaptop struct {
+	sequence uint64
+	ID       []byte
+}
+
+// scanIndex returns the entries of the index whose keys start with a prefix
+// between min and max, in the order the laptops were created
+func scanIndex(index *bolt.Bucket, min []byte, max []byte) []indexedLaptop {
+	entries := []indexedLaptop{}
+	cursor := index.Cursor()
+	for key, ID := cursor.Seek(min); key != nil && bytes.Compare(key[:len(max)], max) <= 0; key, ID = cursor.Next() {
+		entries = append(entries, indexedLaptop{
+			sequence: binary.BigEndian.Uint64(key[len(key)-8:]),
+			ID:       ID,
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].sequence < entries[j].sequence
+	})
+	return entries
+}
+
+// boltCandidates returns the laptops created after the sequence that may match
+// the filter, in the order they were created. The release year index is
+// scanned when the filter bounds the release year on both sides, the price
+// index otherwise. Every laptop is scanned if the filter is nil.
+func boltCandidates(tx *bolt.Tx, filter *pb.Filter, afterSequence uint64) []indexedLaptop {
+	switch {
+	case filter == nil:
+		entries := []indexedLaptop{}
+		cursor := tx.Bucket(createdBucket).Cursor()
+		for key, ID := cursor.Seek(encodeUint64(afterSequence + 1)); key != nil; key, ID = cursor.Next() {
+			entries = append(entries, indexedLaptop{sequence: binary.BigEndian.Uint64(key), ID: ID})
+		}
+		return entries
+	case filter.GetMinReleaseYear() > 0 && filter.GetMaxReleaseYear() > 0:
+		min := make([]byte, 4)
+		binary.BigEndian.PutUint32(min, filter.GetMinReleaseYear())
+		max := make([]byte, 4)
+		binary.BigEndian.PutUint32(max, filter.GetMaxReleaseYear())
+		return createdAfter(scanIndex(tx.Bucket(releaseYearIndexBucket), min, max), afterSequence)
+	default:
+		min := encodeFloat64(filter.GetMinPriceUsd())
+		max := encodeFloat64(filter.GetMaxPriceUsd())
+		return createdAfter(scanIndex(tx.Bucket(priceIndexBucket), min, max), afterSequence)
+	}
+}
+
+// createdAfter returns the entries created after the sequence
+func createdAfter(entries []indexedLaptop, afterSequence uint64) []indexedLaptop {
+	i := sort.Search(len(entries), func(i int) bool {
+		return entries[i].sequence > afterSequence
+	})
+	return entries[i:]
+}
+
+// selectBoltLaptops returns the laptops created after the sequence that match
+// the filter, if not nil, and are not deleted unless showDeleted is set
+func selectBoltLaptops(tx *bolt.Tx, filter *pb.Filter, showDeleted bool, afterSequence uint64) ([]*pb.Laptop, []uint64, error) {
+	laptops := []*pb.Laptop{}
+	sequences := []uint64{}
+	for _, entry := range boltCandidates(tx, filter, afterSequence) {
+		laptop, err := getBoltLaptop(tx, entry.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if laptop.GetDeletedAt() != nil && !showDeleted {
+			continue
+		}
+		if filter != nil && !isQualified(filter, laptop) {
+			continue
+		}
+
+		laptops = append(laptops, laptop)
+		sequences = append(sequences, entry.sequence)
+	}
+
+	return laptops, sequences, nil
+}
+
+// Search scans an index bucket by range for the laptops matching the filter.
+// Expressions, text relevance and ordering are then evaluated on these laptops.
+func (store *BoltLaptopStore) Search(query *SearchQuery, found func(*pb.Laptop) error) (string, error) {
+	return store.searchLaptops(query, func(cursor *searchResult) ([]*pb.Laptop, []uint64, error) {
+		var filter *pb.Filter
+		if query.appliesFilter() {
+			filter = query.Filter
+			if filter == nil {
+				filter = &pb.Filter{}
+			}
+		}
+
+		// laptops created before the cursor cannot be on the next page of the default order
+		afterSequence := uint64(0)
+		if cursor != nil && query.ordering().GetField() == pb.OrderBy_DEFAULT {
+			afterSequence = cursor.sequence
+		}
+
+		var laptops []*pb.Laptop
+		var sequences []uint64
+		err := store.db.View(func(tx *bolt.Tx) error {
+			var err error
+			laptops, sequences, err = selectBoltLaptops(tx, filter, query.ShowDeleted, afterSequence)
+			return err
+		})
+		return laptops, sequences, err
+	}, found)
+}
+
+func (store *BoltLaptopStore) Facets(filter *pb.Filter, widths HistogramWidths) (*pb.SearchFacetsResponse, error) {
+	if filter == nil {
+		filter = &pb.Filter{}
+	}
+
+	var laptops []*pb.Laptop
+	err := store.db.View(func(tx *bolt.Tx) error {
+		var err error
+		laptops, _, err = selectBoltLaptops(tx, filter, false, 0)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	counter := newFacetCounter(widths)
+	for _, laptop := range laptops {
+		counter.add(laptop)
+	}
+
+	return counter.response(), nil
+}
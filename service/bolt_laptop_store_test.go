@@ -0,0 +1,228 @@
+package service_test
+
+import (
+	"context"
+	"gRPC/pb"
+	"gRPC/sample"
+	"gRPC/service"
+	"math/rand"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func newTestBoltLaptopStore(t *testing.T) (*service.BoltLaptopStore, string) {
+	path := filepath.Join(t.TempDir(), "laptop.bolt")
+	store, err := service.NewBoltLaptopStore(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+	return store, path
+}
+
+func TestBoltLaptopStoreFilter(t *testing.T) {
+	t.Parallel()
+
+	seed := time.Now().UnixNano()
+	t.Logf("seed %d", seed)
+	random := rand.New(rand.NewSource(seed))
+
+	boltStore, _ := newTestBoltLaptopStore(t)
+	memoryStore := service.NewInMemoryLaptopStore()
+
+	laptops := []*pb.Laptop{}
+	for i := 0; i < 200; i++ {
+		laptop := sample.NewLaptop()
+		err := boltStore.Save(proto.Clone(laptop).(*pb.Laptop))
+		require.NoError(t, err)
+		err = memoryStore.Save(laptop)
+		require.NoError(t, err)
+		laptops = append(laptops, laptop)
+	}
+
+	// updates move the index entries of the laptops
+	for _, laptop := range laptops[:50] {
+		patch := &pb.Laptop{PriceUsd: 1500 + random.Float64()*2000, ReleaseYear: uint32(2015 + random.Intn(5))}
+		paths := []string{"price_usd", "release_year"}
+		_, err := boltStore.Patch(laptop.Id, patch, paths, 0)
+		require.NoError(t, err)
+		_, err = memoryStore.Patch(laptop.Id, patch, paths, 0)
+		require.NoError(t, err)
+	}
+
+	// the indexes are range scanned, so they must select the same laptops as a full scan
+	for i := 0; i < 300; i++ {
+		filter := randomTestFilter(random)
+		query := &service.SearchQuery{Filter: filter}
+		require.Equal(t, searchIDs(t, memoryStore, query), searchIDs(t, boltStore, query), "filter: %v", filter)
+
+		expected, err := memoryStore.Facets(filter, service.HistogramWidths{})
+		require.NoError(t, err)
+		actual, err := boltStore.Facets(filter, service.HistogramWidths{})
+		require.NoError(t, err)
+		require.True(t, proto.Equal(expected, actual), "filter: %v", filter)
+	}
+
+	for _, orderBy := range []*pb.OrderBy{nil, {Field: pb.OrderBy_PRICE_USD, Descending: true}} {
+		query := &service.SearchQuery{
+			Filter:   &pb.Filter{MaxPriceUsd: 3000, MinReleaseYear: 2016, MaxReleaseYear: 2018},
+			OrderBy:  orderBy,
+			PageSize: 7,
+		}
+		for {
+			expectedIDs := []string{}
+			expectedToken, err := memoryStore.Search(query, func(laptop *pb.Laptop) error {
+				expectedIDs = append(expectedIDs, laptop.Id)
+				return nil
+			})
+			require.NoError(t, err)
+
+			actualIDs := []string{}
+			actualToken, err := boltStore.Search(query, func(laptop *pb.Laptop) error {
+				actualIDs = append(actualIDs, laptop.Id)
+				return nil
+			})
+			require.NoError(t, err)
+			require.Equal(t, expectedIDs, actualIDs)
+			require.Equal(t, expectedToken, actualToken)
+
+			if actualToken == "" {
+				break
+			}
+			query.PageToken = actualToken
+		}
+	}
+}
+
+func TestBoltLaptopStore(t *testing.T) {
+	t.Parallel()
+
+	store, path := newTestBoltLaptopStore(t)
+
+	watch, err := store.WatchChanges(0)
+	require.NoError(t, err)
+	defer watch.Close()
+
+	laptop := sample.NewLaptop()
+	err = store.Save(laptop)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), laptop.Revision)
+
+	err = store.Save(laptop)
+	require.ErrorIs(t, err, service.ErrAlreadyExists)
+
+	laptop.Name = "Updated"
+	err = store.Update(laptop, 1)
+	require.NoError(t, err)
+	err = store.Update(laptop, 1)
+	require.ErrorIs(t, err, service.ErrRevisionMismatch)
+
+	second := sample.NewLaptop()
+	errs := store.SaveAll([]*pb.Laptop{second, laptop})
+	require.ErrorIs(t, errs[1], service.ErrAlreadyExists)
+	other, err := store.Find(second.Id)
+	require.NoError(t, err)
+	require.Nil(t, other)
+
+	errs = store.SaveAll([]*pb.Laptop{second})
+	require.Equal(t, []error{nil}, errs)
+
+	err = store.Delete(laptop.Id, 0)
+	require.NoError(t, err)
+	other, err = store.Find(laptop.Id)
+	require.NoError(t, err)
+	require.Nil(t, other)
+
+	filter := &pb.Filter{MaxPriceUsd: 5000}
+	require.Equal(t, []string{second.Id}, searchIDs(t, store, &service.SearchQuery{Filter: filter}))
+	require.Equal(t, []string{laptop.Id, second.Id}, searchIDs(t, store, &service.SearchQuery{Filter: filter, ShowDeleted: true}))
+
+	restored, err := store.Undelete(laptop.Id, 0)
+	require.NoError(t, err)
+	require.Nil(t, restored.DeletedAt)
+
+	err = store.Delete(second.Id, 0)
+	require.NoError(t, err)
+	purged, err := store.Purge(time.Now().Add(time.Second))
+	require.NoError(t, err)
+	require.Equal(t, []string{second.Id}, purged)
+	require.Equal(t, []string{laptop.Id}, searchIDs(t, store, &service.SearchQuery{Filter: filter, ShowDeleted: true}))
+
+	types := []pb.LaptopChange_Type{}
+	for i := 0; i < 7; i++ {
+		change, err := watch.Next(context.Background())
+		require.NoError(t, err)
+		types = append(types, change.Type)
+	}
+	require.Equal(t, []pb.LaptopChange_Type{
+		pb.LaptopChange_CREATED,
+		pb.LaptopChange_UPDATED,
+		pb.LaptopChange_CREATED,
+		pb.LaptopChange_DELETED,
+		pb.LaptopChange_UNDELETED,
+		pb.LaptopChange_DELETED,
+		pb.LaptopChange_PURGED,
+	}, types)
+
+	// laptops and revisions are kept in the file
+	require.NoError(t, store.Close())
+	reopened, err := service.NewBoltLaptopStore(path)
+	require.NoError(t, err)
+	defer reopened.Close()
+
+	other, err = reopened.Find(laptop.Id)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(restored, other))
+
+	third := sample.NewLaptop()
+	err = reopened.Save(third)
+	require.NoError(t, err)
+	require.Greater(t, third.Revision, restored.Revision)
+	require.Equal(t, []string{laptop.Id, third.Id}, searchIDs(t, reopened, &service.SearchQuery{Filter: filter}))
+
+	// the text index is rebuilt from the file
+	require.Equal(t, []string{laptop.Id}, searchIDs(t, reopened, &service.SearchQuery{Filter: filter, Text: "updated"}))
+}
+
+func TestBoltLaptopStoreConcurrentReaders(t *testing.T) {
+	t.Parallel()
+
+	store, _ := newTestBoltLaptopStore(t)
+	laptops := []*pb.Laptop{}
+	for i := 0; i < 20; i++ {
+		laptop := sample.NewLaptop()
+		err := store.Save(laptop)
+		require.NoError(t, err)
+		laptops = append(laptops, laptop)
+	}
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				laptop := proto.Clone(laptops[(i*20+j)%len(laptops)]).(*pb.Laptop)
+				laptop.PriceUsd = float64(1000 + j)
+				_, err := store.Upsert(laptop)
+				require.NoError(t, err)
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				// every laptop stays in the price range, so a search sees all of them
+				ids := searchIDs(t, store, &service.SearchQuery{Filter: &pb.Filter{MaxPriceUsd: 5000}})
+				require.Len(t, ids, len(laptops))
+
+				other, err := store.Find(laptops[j].Id)
+				require.NoError(t, err)
+				require.Equal(t, laptops[j].Id, other.Id)
+			}
+		}()
+	}
+	wg.Wait()
+}
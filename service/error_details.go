@@ -0,0 +1,80 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorDetails are the details of an error returned by LaptopServer, decoded on the client side
+type ErrorDetails struct {
+	Code    codes.Code
+	Message string
+
+	// Info tells why the request failed, it is nil if the error carries no ErrorInfo
+	Info *errdetails.ErrorInfo
+	// Resource is the resource that is missing, already exists or was modified concurrently
+	Resource *errdetails.ResourceInfo
+	// FieldViolations are the invalid fields of the request
+	FieldViolations []*errdetails.BadRequest_FieldViolation
+}
+
+// ParseErrorDetails decodes the details attached to the status of a gRPC error
+func ParseErrorDetails(err error) *ErrorDetails {
+	st := status.Convert(err)
+	details := &ErrorDetails{
+		Code:    st.Code(),
+		Message: st.Message(),
+	}
+
+	for _, detail := range st.Details() {
+		switch detail := detail.(type) {
+		case *errdetails.ErrorInfo:
+			details.Info = detail
+		case *errdetails.ResourceInfo:
+			details.Resource = detail
+		case *errdetails.BadRequest:
+			details.FieldViolations = append(details.FieldViolations, detail.GetFieldViolations()...)
+		}
+	}
+
+	return details
+}
+
+// Reason returns the reason of the ErrorInfo detail, or an empty string if there is none
+func (details *ErrorDetails) Reason() string {
+	return details.Info.GetReason()
+}
+
+func (details *ErrorDetails) String() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "%s: %s", details.Code, details.Message)
+
+	if details.Info != nil {
+		fmt.Fprintf(&builder, " (reason %s", details.Info.GetReason())
+
+		keys := make([]string, 0, len(details.Info.GetMetadata()))
+		for key := range details.Info.GetMetadata() {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Fprintf(&builder, ", %s=%s", key, details.Info.GetMetadata()[key])
+		}
+		builder.WriteString(")")
+	}
+
+	if details.Resource != nil {
+		fmt.Fprintf(&builder, "; %s %s %s", details.Resource.GetResourceType(), details.Resource.GetResourceName(), details.Resource.GetDescription())
+	}
+
+	for _, violation := range details.FieldViolations {
+		fmt.Fprintf(&builder, "; %s %s", violation.GetField(), violation.GetDescription())
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"gRPC/pb"
+	"math"
+	"sort"
+)
+
+// default widths of the histogram buckets
+const (
+	defaultPriceUsdBucketWidth    = 500
+	defaultRamGbBucketWidth       = 8
+	defaultReleaseYearBucketWidth = 1
+)
+
+const bitsPerGigabyte = 1 << 33
+
+// maxBucketNumber bounds the bucket numbers of the histograms, so that they
+// fit in an int64 and convert to float64 exactly
+const maxBucketNumber = 1 << 53
+
+// minBucketWidth keeps the bucket numbers of the largest RAM sizes and release
+// years below maxBucketNumber. Larger prices are counted in the last bucket.
+const minBucketWidth = 1e-6
+
+// HistogramWidths are the bucket widths of the histograms computed by LaptopStore.Facets
+type HistogramWidths struct {
+	PriceUsd    float64
+	RamGb       float64
+	ReleaseYear float64
+}
+
+// withDefaults replaces unset and invalid widths with their default
+func (widths HistogramWidths) withDefaults() HistogramWidths {
+	if !validBucketWidth(widths.PriceUsd) || widths.PriceUsd == 0 {
+		widths.PriceUsd = defaultPriceUsdBucketWidth
+	}
+	if !validBucketWidth(widths.RamGb) || widths.RamGb == 0 {
+		widths.RamGb = defaultRamGbBucketWidth
+	}
+	if !validBucketWidth(widths.ReleaseYear) || widths.ReleaseYear == 0 {
+		widths.ReleaseYear = defaultReleaseYearBucketWidth
+	}
+
+	return widths
+}
+
+// validBucketWidth reports whether the width is unset, which selects the
+// default, or a finite number not less than minBucketWidth. NaN is not valid.
+func validBucketWidth(width float64) bool {
+	return width == 0 || (width >= minBucketWidth && !math.IsInf(width, 0))
+}
+
+// facetCounter aggregates the facets of the laptops added to it
+type facetCounter struct {
+	widths HistogramWidths
+	total  uint32
+
+	brands          map[string]uint32
+	cpuBrands       map[string]uint32
+	gpuBrands       map[string]uint32
+	keyboardLayouts map[string]uint32
+	screenPannels   map[string]uint32
+	storageDrivers  map[string]uint32
+
+	// histograms map bucket numbers to counts
+	priceUsd    map[int64]uint32
+	ramGb       map[int64]uint32
+	releaseYear map[int64]uint32
+}
+
+func newFacetCounter(widths HistogramWidths) *facetCounter {
+	return &facetCounter{
+		widths:          widths.withDefaults(),
+		brands:          make(map[string]uint32),
+		cpuBrands:       make(map[string]uint32),
+		gpuBrands:       make(map[string]uint32),
+		keyboardLayouts: make(map[string]uint32),
+		screenPannels:   make(map[string]uint32),
+		storageDrivers:  make(map[string]uint32),
+		priceUsd:        make(map[int64]uint32),
+		ramGb:           make(map[int64]uint32),
+		releaseYear:     make(map[int64]uint32),
+	}
+}
+
+func (counter *facetCounter) add(laptop *pb.Laptop) {
+	counter.total++
+
+	counter.brands[laptop.GetBrand()]++
+	counter.cpuBrands[laptop.GetCpu().GetBrand()]++
+	counter.keyboardLayouts[laptop.GetKeyboard().GetLayout().String()]++
+	counter.screenPannels[laptop.GetScreen().GetPannel().String()]++
+
+	gpuBrands := make(map[string]bool)
+	for _, gpu := range laptop.GetGpus() {
+		gpuBrands[gpu.GetBrand()] = true
+	}
+	for brand := range gpuBrands {
+		counter.gpuBrands[brand]++
+	}
+
+	drivers := make(map[string]bool)
+	for _, storage := range laptop.GetStorages() {
+		drivers[storage.GetDriver().String()] = true
+	}
+	for driver := range drivers {
+		counter.storageDrivers[driver]++
+	}
+
+	counter.priceUsd[bucket(laptop.GetPriceUsd(), counter.widths.PriceUsd)]++
+	counter.ramGb[bucket(float64(toBit(laptop.GetRam()))/bitsPerGigabyte, counter.widths.RamGb)]++
+	counter.releaseYear[bucket(float64(laptop.GetReleaseYear()), counter.widths.ReleaseYear)]++
+}
+
+func (counter *facetCounter) response() *pb.SearchFacetsResponse {
+	return &pb.SearchFacetsResponse{
+		Total:           counter.total,
+		Brands:          facetCounts(counter.brands),
+		CpuBrands:       facetCounts(counter.cpuBrands),
+		GpuBrands:       facetCounts(counter.gpuBrands),
+		KeyboardLayouts: facetCounts(counter.keyboardLayouts),
+		ScreenPannels:   facetCounts(counter.screenPannels),
+		StorageDrivers:  facetCounts(counter.storageDrivers),
+		PriceUsd:        histogram(counter.priceUsd, counter.widths.PriceUsd),
+		RamGb:           histogram(counter.ramGb, counter.widths.RamGb),
+		ReleaseYear:     histogram(counter.releaseYear, counter.widths.ReleaseYear),
+	}
+}
+
+// bucket returns the number of the bucket holding the value, clamped to maxBucketNumber
+func bucket(value float64, width float64) int64 {
+	number := math.Floor(value / width)
+	if number > maxBucketNumber {
+		return maxBucketNumber
+	}
+	if number < -maxBucketNumber {
+		return -maxBucketNumber
+	}
+	return int64(number)
+}
+
+// facetCounts sorts the counts by decreasing count and then by value
+func facetCounts(counts map[string]uint32) []*pb.FacetCount {
+	facets := make([]*pb.FacetCount, 0, len(counts))
+	for value, count := range counts {
+		facets = append(facets, &pb.FacetCount{Value: value, Count: count})
+	}
+
+	sort.Slice(facets, func(i, j int) bool {
+		if facets[i].Count != facets[j].Count {
+			return facets[i].Count > facets[j].Count
+		}
+		return facets[i].Value < facets[j].Value
+	})
+
+	return facets
+}
+
+func histogram(counts map[int64]uint32, width float64) []*pb.HistogramBucket {
+	buckets := make([]*pb.HistogramBucket, 0, len(counts))
+	for number, count := range counts {
+		buckets = append(buckets, &pb.HistogramBucket{
+			Min:   float64(number) * width,
+			Max:   float64(number+1) * width,
+			Count: count,
+		})
+	}
+
+	sort.Slice(buckets, func(i, j int) bool {
+		return buckets[i].Min < buckets[j].Min
+	})
+
+	return buckets
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var ErrInvalidFieldMask = errors.New("invalid field mask")
+
+// immutableLaptopPaths lists the laptop fields that cannot be changed by a
+// patch, neither as a whole nor through their sub-paths. deleted_at is changed
+// by deleting and restoring the laptop.
+var immutableLaptopPaths = map[string]bool{
+	"id":         true,
+	"revision":   true,
+	"deleted_at": true,
+}
+
+// validateFieldMask checks that every path names a field (or a oneof) of the message
+func validateFieldMask(message proto.Message, paths []string) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("%w: no path is given", ErrInvalidFieldMask)
+	}
+
+	descriptor := message.ProtoReflect().Descriptor()
+	for _, path := range paths {
+		if immutableLaptopPaths[strings.SplitN(path, ".", 2)[0]] {
+			return fmt.Errorf("%w: path %q cannot be changed", ErrInvalidFieldMask, path)
+		}
+
+		_, _, err := resolvePath(descriptor, path)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resolvePath walks a dot separated path through the message descriptor.
+// It returns the descriptor of the innermost message together with either
+// the field or the oneof that the last path segment refers to.
+func resolvePath(descriptor protoreflect.MessageDescriptor, path string) (protoreflect.FieldDescriptor, protoreflect.OneofDescriptor, error) {
+	names := strings.Split(path, ".")
+
+	for i, name := range names {
+		last := i == len(names)-1
+
+		field := descriptor.Fields().ByName(protoreflect.Name(name))
+		if field == nil {
+			oneof := descriptor.Oneofs().ByName(protoreflect.Name(name))
+			if oneof != nil && last {
+				return nil, oneof, nil
+			}
+
+			return nil, nil, fmt.Errorf("%w: unknown path %q", ErrInvalidFieldMask, path)
+		}
+
+		if last {
+			return field, nil, nil
+		}
+
+		if field.Message() == nil || field.IsList() || field.IsMap() {
+			return nil, nil, fmt.Errorf("%w: path %q goes through a non-message field", ErrInvalidFieldMask, path)
+		}
+
+		descriptor = field.Message()
+	}
+
+	return nil, nil, fmt.Errorf("%w: empty path", ErrInvalidFieldMask)
+}
+
+// applyFieldMask copies the values addressed by paths from src into dst.
+// A path whose value is unset in src clears the value in dst.
+func applyFieldMask(dst proto.Message, src proto.Message, paths []string) error {
+	err := validateFieldMask(dst, paths)
+	if err != nil {
+		return err
+	}
+
+	// clone the source so that dst never shares sub-messages with the caller
+	src = proto.Clone(src)
+
+	for _, path := range paths {
+		names := strings.Split(path, ".")
+
+		to := dst.ProtoReflect()
+		from := src.ProtoReflect()
+		for _, name := range names[:len(names)-1] {
+			field := to.Descriptor().Fields().ByName(protoreflect.Name(name))
+			to = to.Mutable(field).Message()
+			from = from.Get(field).Message()
+		}
+
+		field, oneof, err := resolvePath(to.Descriptor(), names[len(names)-1])
+		if err != nil {
+			return err
+		}
+
+		if oneof != nil {
+			if current := to.WhichOneof(oneof); current != nil {
+				to.Clear(current)
+			}
+			field = from.WhichOneof(oneof)
+			if field == nil {
+				continue
+			}
+		}
+
+		if from.Has(field) {
+			to.Set(field, from.Get(field))
+		} else {
+			to.Clear(field)
+		}
+	}
+
+	return nil
+}
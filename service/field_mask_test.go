@@ -0,0 +1,63 @@
+package service
+
+import (
+	"gRPC/pb"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestValidateFieldMask(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name  string
+		paths []string
+		valid bool
+	}{
+		{name: "field", paths: []string{"name"}, valid: true},
+		{name: "nested_field", paths: []string{"cpu.name"}, valid: true},
+		{name: "oneof", paths: []string{"weight"}, valid: true},
+		{name: "timestamp_field", paths: []string{"updated_at.seconds"}, valid: true},
+		{name: "empty", paths: nil},
+		{name: "unknown", paths: []string{"cpu.unknown"}},
+		{name: "id", paths: []string{"id"}},
+		{name: "revision", paths: []string{"revision"}},
+		{name: "revision_sub_path", paths: []string{"revision.value"}},
+		{name: "deleted_at", paths: []string{"deleted_at"}},
+		{name: "deleted_at_sub_path", paths: []string{"deleted_at.seconds"}},
+		{name: "deleted_at_after_valid_path", paths: []string{"name", "deleted_at.nanos"}},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validateFieldMask(&pb.Laptop{}, tc.paths)
+			if tc.valid {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, ErrInvalidFieldMask)
+			}
+		})
+	}
+}
+
+func TestInMemoryLaptopStorePatchDeletedAt(t *testing.T) {
+	t.Parallel()
+
+	store := NewInMemoryLaptopStore()
+	laptop := &pb.Laptop{Id: "laptop"}
+	require.NoError(t, store.Save(laptop))
+
+	_, err := store.Patch(laptop.Id, &pb.Laptop{DeletedAt: timestamppb.Now()}, []string{"deleted_at.seconds"}, 0)
+	require.ErrorIs(t, err, ErrInvalidFieldMask)
+
+	found, err := store.Find(laptop.Id)
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	require.Nil(t, found.DeletedAt)
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"fmt"
+	"gRPC/pb"
+	"gRPC/serializer"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	laptopFileExtension = ".bin"
+	tempFileExtension   = serializer.TempFileExtension
+)
+
+// FileLaptopStore is a LaptopStore that keeps its laptops in memory and
+// persists each of them to a binary protobuf file in a directory. Every write
+// syncs a temporary file, renames it over the file of the laptop and syncs the
+// directory, so a crash leaves either the old or the new version on disk. The
+// file names start with the sequence in which the laptops were created, so the
+// laptops are listed in the same order after a restart.
+type FileLaptopStore struct {
+	*persistentLaptopStore
+	folder string
+}
+
+// NewFileLaptopStore returns a store persisting laptops to the folder, which is
+// created if needed. The laptops already in the folder are loaded first.
+func NewFileLaptopStore(folder string) (*FileLaptopStore, error) {
+	err := os.MkdirAll(folder, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create laptop folder: %w", err)
+	}
+
+	store := &FileLaptopStore{
+		folder: folder,
+	}
+	store.persistentLaptopStore = newPersistentLaptopStore(NewInMemoryLaptopStore(), store)
+
+	err = store.loadFolder()
+	if err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+func (store *FileLaptopStore) loadFolder() error {
+	// the entries are sorted by file name, so by creation sequence
+	entries, err := os.ReadDir(store.folder)
+	if err != nil {
+		return fmt.Errorf("cannot read laptop folder: %w", err)
+	}
+
+	laptops := []*pb.Laptop{}
+	sequences := []uint64{}
+	for _, entry := range entries {
+		name := entry.Name()
+		path := filepath.Join(store.folder, name)
+
+		// a temporary file is left behind by a write that did not finish
+		if strings.HasSuffix(name, tempFileExtension) {
+			err = os.Remove(path)
+			if err != nil {
+				return fmt.Errorf("cannot remove temporary file %s: %w", name, err)
+			}
+			continue
+		}
+
+		if entry.IsDir() || !strings.HasSuffix(name, laptopFileExtension) {
+			continue
+		}
+
+		sequence, err := parseLaptopFileName(name)
+		if err != nil {
+			return err
+		}
+
+		laptop := &pb.Laptop{}
+		err = serializer.ReadProtobufFromBinaryFile(path, laptop)
+		if err != nil {
+			return fmt.Errorf("cannot read laptop file %s: %w", name, err)
+		}
+		laptops = append(laptops, laptop)
+		sequences = append(sequences, sequence)
+	}
+
+	return store.InMemoryLaptopStore.load(laptops, sequences)
+}
+
+// parseLaptopFileName returns the creation sequence in the name of a laptop file
+func parseLaptopFileName(name string) (uint64, error) {
+	prefix := strings.SplitN(name, "_", 2)[0]
+	sequence, err := strconv.ParseUint(prefix, 10, 64)
+	if err != nil || sequence == 0 {
+		return 0, fmt.Errorf("invalid laptop file name %s", name)
+	}
+
+	return sequence, nil
+}
+
+// path returns the path of the file of the laptop created with the sequence
+func (store *FileLaptopStore) path(laptopID string, sequence uint64) string {
+	return filepath.Join(store.folder, fmt.Sprintf("%020d_%s%s", sequence, laptopID, laptopFileExtension))
+}
+
+// persist writes the laptops to their files. Several laptops are only written
+// together when they are created, so the files written before a failure are
+// removed again.
+func (store *FileLaptopStore) persist(laptops []*pb.Laptop, sequences []uint64) error {
+	for i, laptop := range laptops {
+		err := serializer.WriteProtobufToBinaryFileAtomic(laptop, store.path(laptop.Id, sequences[i]))
+		if err != nil {
+			for j, written := range laptops[:i] {
+				os.Remove(store.path(written.Id, sequences[j]))
+			}
+			return fmt.Errorf("cannot write laptop file: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// persistRemoved removes the files of the laptops
+func (store *FileLaptopStore) persistRemoved(laptopIDs []string, sequences []uint64) error {
+	for i, laptopID := range laptopIDs {
+		err := os.Remove(store.path(laptopID, sequences[i]))
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("cannot remove laptop file: %w", err)
+		}
+	}
+
+	err := serializer.SyncFolder(store.folder)
+	if err != nil {
+		return fmt.Errorf("cannot sync laptop folder: %w", err)
+	}
+
+	return nil
+}
+
+func (store *FileLaptopStore) committed() {}
@@ -0,0 +1,110 @@
+package service_test
+
+import (
+	"gRPC/pb"
+	"gRPC/sample"
+	"gRPC/service"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestFileLaptopStore(t *testing.T){
+	t.Parallel()
+
+	folder := t.TempDir()
+	store, err := service.NewFileLaptopStore(folder)
+	require.NoError(t, err)
+
+	laptop := sample.NewLaptop()
+	err = store.Save(laptop)
+	require.NoError(t, err)
+
+	updated := sample.NewLaptop()
+	err = store.Save(updated)
+	require.NoError(t, err)
+	updated.Name = "Updated"
+	err = store.Update(updated, 0)
+	require.NoError(t, err)
+
+	deleted := sample.NewLaptop()
+	err = store.Save(deleted)
+	require.NoError(t, err)
+	err = store.Delete(deleted.Id, 0)
+	require.NoError(t, err)
+
+	// a write interrupted by a crash leaves a temporary file behind
+	tempFile := filepath.Join(folder, "00000000000000000009_"+sample.NewLaptop().Id+".bin.tmp")
+	err = os.WriteFile(tempFile, []byte("partial"), 0644)
+	require.NoError(t, err)
+
+	reopened, err := service.NewFileLaptopStore(folder)
+	require.NoError(t, err)
+
+	for _, expected := range []*pb.Laptop{laptop, updated}{
+		other, err := reopened.Find(expected.Id)
+		require.NoError(t, err)
+		require.True(t, proto.Equal(expected, other))
+	}
+
+	other, err := reopened.Find(deleted.Id)
+	require.NoError(t, err)
+	require.Nil(t, other)
+
+	filter := &pb.Filter{
+		MaxPriceUsd: 5000,
+	}
+	require.Equal(t, []string{laptop.Id, updated.Id}, searchIDs(t, reopened, &service.SearchQuery{Filter: filter}))
+	require.ElementsMatch(t, []string{laptop.Id, updated.Id, deleted.Id}, searchIDs(t, reopened, &service.SearchQuery{Filter: filter, ShowDeleted: true}))
+
+	_, err = os.Stat(tempFile)
+	require.True(t, os.IsNotExist(err))
+
+	// revisions keep increasing after a restart
+	err = reopened.Update(laptop, laptop.Revision)
+	require.NoError(t, err)
+	require.Greater(t, laptop.Revision, deleted.Revision)
+
+	restored, err := reopened.Undelete(deleted.Id, 0)
+	require.NoError(t, err)
+	require.Nil(t, restored.DeletedAt)
+
+	err = reopened.Delete(restored.Id, 0)
+	require.NoError(t, err)
+	purged, err := reopened.Purge(time.Now().Add(time.Second))
+	require.NoError(t, err)
+	require.Equal(t, []string{deleted.Id}, purged)
+
+	files, err := filepath.Glob(filepath.Join(folder, "*"+deleted.Id+"*"))
+	require.NoError(t, err)
+	require.Empty(t, files)
+
+	// a page token handed out before a restart still points to the same place
+	// and the laptops keep their creation order, although laptop has the last revision
+	pageToken, err := reopened.Search(&service.SearchQuery{Filter: filter, PageSize: 1}, func(*pb.Laptop) error { return nil })
+	require.NoError(t, err)
+	require.NotEmpty(t, pageToken)
+
+	reopened, err = service.NewFileLaptopStore(folder)
+	require.NoError(t, err)
+	other, err = reopened.Find(laptop.Id)
+	require.NoError(t, err)
+	require.Equal(t, laptop.Revision, other.Revision)
+	require.Equal(t, []string{laptop.Id, updated.Id}, searchIDs(t, reopened, &service.SearchQuery{Filter: filter, ShowDeleted: true}))
+	require.Equal(t, []string{updated.Id}, searchIDs(t, reopened, &service.SearchQuery{Filter: filter, PageToken: pageToken}))
+}
+
+func TestFileLaptopStoreInvalidFileName(t *testing.T){
+	t.Parallel()
+
+	folder := t.TempDir()
+	err := os.WriteFile(filepath.Join(folder, sample.NewLaptop().Id+".bin"), nil, 0644)
+	require.NoError(t, err)
+
+	_, err = service.NewFileLaptopStore(folder)
+	require.Error(t, err)
+}
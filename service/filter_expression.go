@@ -0,0 +1,374 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"gRPC/pb"
+	"math"
+	"strings"
+)
+
+var ErrInvalidExpression = errors.New("invalid filter expression")
+
+// maxExpressionDepth limits how deeply filter expressions can be nested
+const maxExpressionDepth = 32
+
+// laptopPredicate reports whether a laptop matches some criteria
+type laptopPredicate func(laptop *pb.Laptop) bool
+
+// laptopField describes a laptop field that predicates can compare.
+// Exactly one of the getters is set, depending on the type of the field.
+type laptopField struct {
+	texts    func(laptop *pb.Laptop) []string
+	numbers  func(laptop *pb.Laptop) []float64
+	flags    func(laptop *pb.Laptop) []bool
+	memories func(laptop *pb.Laptop) []uint64 // in bits
+}
+
+// laptopFields lists the fields that can be used in predicates by their path
+var laptopFields = map[string]*laptopField{
+	"brand": {texts: func(laptop *pb.Laptop) []string {
+		return []string{laptop.GetBrand()}
+	}},
+	"name": {texts: func(laptop *pb.Laptop) []string {
+		return []string{laptop.GetName()}
+	}},
+	"price_usd": {numbers: func(laptop *pb.Laptop) []float64 {
+		return []float64{laptop.GetPriceUsd()}
+	}},
+	"release_year": {numbers: func(laptop *pb.Laptop) []float64 {
+		return []float64{float64(laptop.GetReleaseYear())}
+	}},
+	"weight_kg": {numbers: func(laptop *pb.Laptop) []float64 {
+		return []float64{weightKg(laptop)}
+	}},
+	"weight_lb": {numbers: func(laptop *pb.Laptop) []float64 {
+		return []float64{weightKg(laptop) / poundToKg}
+	}},
+	"cpu.brand": {texts: func(laptop *pb.Laptop) []string {
+		return []string{laptop.GetCpu().GetBrand()}
+	}},
+	"cpu.name": {texts: func(laptop *pb.Laptop) []string {
+		return []string{laptop.GetCpu().GetName()}
+	}},
+	"cpu.number_cores": {numbers: func(laptop *pb.Laptop) []float64 {
+		return []float64{float64(laptop.GetCpu().GetNumberCores())}
+	}},
+	"cpu.number_threads": {numbers: func(laptop *pb.Laptop) []float64 {
+		return []float64{float64(laptop.GetCpu().GetNumberThreads())}
+	}},
+	"cpu.min_ghz": {numbers: func(laptop *pb.Laptop) []float64 {
+		return []float64{laptop.GetCpu().GetMinGhz()}
+	}},
+	"cpu.max_ghz": {numbers: func(laptop *pb.Laptop) []float64 {
+		return []float64{laptop.GetCpu().GetMaxGhz()}
+	}},
+	"ram": {memories: func(laptop *pb.Laptop) []uint64 {
+		return []uint64{toBit(laptop.GetRam())}
+	}},
+	"gpu.brand": {texts: func(laptop *pb.Laptop) []string {
+		values := []string{}
+		for _, gpu := range laptop.GetGpus() {
+			values = append(values, gpu.GetBrand())
+		}
+		return values
+	}},
+	"gpu.name": {texts: func(laptop *pb.Laptop) []string {
+		values := []string{}
+		for _, gpu := range laptop.GetGpus() {
+			values = append(values, gpu.GetName())
+		}
+		return values
+	}},
+	"gpu.memory": {memories: func(laptop *pb.Laptop) []uint64 {
+		values := []uint64{}
+		for _, gpu := range laptop.GetGpus() {
+			values = append(values, toBit(gpu.GetMemory()))
+		}
+		return values
+	}},
+	"storage.driver": {texts: func(laptop *pb.Laptop) []string {
+		values := []string{}
+		for _, storage := range laptop.GetStorages() {
+			values = append(values, storage.GetDriver().String())
+		}
+		return values
+	}},
+	"storage.memory": {memories: func(laptop *pb.Laptop) []uint64 {
+		values := []uint64{}
+		for _, storage := range laptop.GetStorages() {
+			values = append(values, toBit(storage.GetMemory()))
+		}
+		return values
+	}},
+	"ssd": {memories: func(laptop *pb.Laptop) []uint64 {
+		return []uint64{storageSize(laptop, pb.Storage_SSD)}
+	}},
+	"hdd": {memories: func(laptop *pb.Laptop) []uint64 {
+		return []uint64{storageSize(laptop, pb.Storage_HDD)}
+	}},
+	"screen.size_inch": {numbers: func(laptop *pb.Laptop) []float64 {
+		return []float64{float64(laptop.GetScreen().GetSizeInch())}
+	}},
+	"screen.resolution.width": {numbers: func(laptop *pb.Laptop) []float64 {
+		return []float64{float64(laptop.GetScreen().GetResolution().GetWidth())}
+	}},
+	"screen.resolution.height": {numbers: func(laptop *pb.Laptop) []float64 {
+		return []float64{float64(laptop.GetScreen().GetResolution().GetHeight())}
+	}},
+	"screen.pannel": {texts: func(laptop *pb.Laptop) []string {
+		return []string{laptop.GetScreen().GetPannel().String()}
+	}},
+	"screen.multitouch": {flags: func(laptop *pb.Laptop) []bool {
+		return []bool{laptop.GetScreen().GetMultitouch()}
+	}},
+	"keyboard.layout": {texts: func(laptop *pb.Laptop) []string {
+		return []string{laptop.GetKeyboard().GetLayout().String()}
+	}},
+	"keyboard.backlit": {flags: func(laptop *pb.Laptop) []bool {
+		return []bool{laptop.GetKeyboard().GetBacklit()}
+	}},
+}
+
+// combineExpressions returns an expression matching both a and b, either of which may be nil
+func combineExpressions(a *pb.FilterExpression, b *pb.FilterExpression) *pb.FilterExpression {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+
+	return &pb.FilterExpression{
+		Node: &pb.FilterExpression_And{And: &pb.FilterExpression_List{Expressions: []*pb.FilterExpression{a, b}}},
+	}
+}
+
+// compileExpression turns a filter expression into a predicate, checking that it is well formed
+func compileExpression(expression *pb.FilterExpression) (laptopPredicate, error) {
+	return compileNode(expression, "expression", 0)
+}
+
+func compileNode(expression *pb.FilterExpression, path string, depth int) (laptopPredicate, error) {
+	if depth > maxExpressionDepth {
+		return nil, fmt.Errorf("%w: %s is nested deeper than %d levels", ErrInvalidExpression, path, maxExpressionDepth)
+	}
+
+	switch node := expression.GetNode().(type) {
+	case *pb.FilterExpression_And:
+		predicates, err := compileList(node.And.GetExpressions(), path+".and", depth)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(laptop *pb.Laptop) bool {
+			for _, predicate := range predicates {
+				if !predicate(laptop) {
+					return false
+				}
+			}
+			return true
+		}, nil
+	case *pb.FilterExpression_Or:
+		predicates, err := compileList(node.Or.GetExpressions(), path+".or", depth)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(laptop *pb.Laptop) bool {
+			for _, predicate := range predicates {
+				if predicate(laptop) {
+					return true
+				}
+			}
+			return false
+		}, nil
+	case *pb.FilterExpression_Not:
+		predicate, err := compileNode(node.Not, path+".not", depth+1)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(laptop *pb.Laptop) bool {
+			return !predicate(laptop)
+		}, nil
+	case *pb.FilterExpression_Predicate:
+		return compilePredicate(node.Predicate, path+".predicate")
+	case *pb.FilterExpression_Filter:
+		filter := node.Filter
+		return func(laptop *pb.Laptop) bool {
+			return isQualified(filter, laptop)
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s is empty", ErrInvalidExpression, path)
+	}
+}
+
+func compileList(expressions []*pb.FilterExpression, path string, depth int) ([]laptopPredicate, error) {
+	if len(expressions) == 0 {
+		return nil, fmt.Errorf("%w: %s has no expressions", ErrInvalidExpression, path)
+	}
+
+	predicates := make([]laptopPredicate, len(expressions))
+	for i, expression := range expressions {
+		predicate, err := compileNode(expression, fmt.Sprintf("%s[%d]", path, i), depth+1)
+		if err != nil {
+			return nil, err
+		}
+		predicates[i] = predicate
+	}
+
+	return predicates, nil
+}
+
+func compilePredicate(predicate *pb.Predicate, path string) (laptopPredicate, error) {
+	field := laptopFields[predicate.GetField()]
+	if field == nil {
+		return nil, fmt.Errorf("%w: %s has unknown field %q", ErrInvalidExpression, path, predicate.GetField())
+	}
+
+	operator := predicate.GetOperator()
+	if operator == pb.Predicate_UNKNOWN {
+		return nil, fmt.Errorf("%w: %s has no operator", ErrInvalidExpression, path)
+	}
+	if _, ok := pb.Predicate_Operator_name[int32(operator)]; !ok {
+		return nil, fmt.Errorf("%w: %s has unknown operator %d", ErrInvalidExpression, path, operator)
+	}
+	ordered := operator != pb.Predicate_EQUAL && operator != pb.Predicate_NOT_EQUAL
+
+	switch value := predicate.GetValue().(type) {
+	case *pb.Predicate_Text:
+		if field.texts == nil || ordered {
+			break
+		}
+
+		return func(laptop *pb.Laptop) bool {
+			for _, text := range field.texts(laptop) {
+				if strings.EqualFold(text, value.Text) {
+					return operator == pb.Predicate_EQUAL
+				}
+			}
+			return operator == pb.Predicate_NOT_EQUAL
+		}, nil
+	case *pb.Predicate_Flag:
+		if field.flags == nil || ordered {
+			break
+		}
+
+		return func(laptop *pb.Laptop) bool {
+			for _, flag := range field.flags(laptop) {
+				if flag == value.Flag {
+					return operator == pb.Predicate_EQUAL
+				}
+			}
+			return operator == pb.Predicate_NOT_EQUAL
+		}, nil
+	case *pb.Predicate_Number:
+		if field.numbers == nil {
+			break
+		}
+		// NaN compares as equal to every number with compareFloat
+		if math.IsNaN(value.Number) || math.IsInf(value.Number, 0) {
+			return nil, fmt.Errorf("%w: %s has a number that is not finite", ErrInvalidExpression, path)
+		}
+
+		return func(laptop *pb.Laptop) bool {
+			numbers := field.numbers(laptop)
+			return matchAny(len(numbers), operator, func(i int) int {
+				return compareFloat(numbers[i], value.Number)
+			})
+		}, nil
+	case *pb.Predicate_Memory:
+		if field.memories == nil {
+			break
+		}
+		if value.Memory.GetUnit() == pb.Memory_UNKNOWN {
+			return nil, fmt.Errorf("%w: %s has a memory value without unit", ErrInvalidExpression, path)
+		}
+
+		bits := toBit(value.Memory)
+		return func(laptop *pb.Laptop) bool {
+			memories := field.memories(laptop)
+			return matchAny(len(memories), operator, func(i int) int {
+				return compareUint(memories[i], bits)
+			})
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s has no value", ErrInvalidExpression, path)
+	}
+
+	return nil, fmt.Errorf("%w: %s cannot compare field %q with %s using %s", ErrInvalidExpression, path, predicate.GetField(), valueType(predicate), operator)
+}
+
+// matchAny reports whether any of the n values satisfies the operator, where
+// compare(i) returns the sign of the comparison between value i and the operand.
+// NOT_EQUAL is satisfied when no value is equal.
+func matchAny(n int, operator pb.Predicate_Operator, compare func(i int) int) bool {
+	if operator == pb.Predicate_NOT_EQUAL {
+		return !matchAny(n, pb.Predicate_EQUAL, compare)
+	}
+
+	for i := 0; i < n; i++ {
+		c := compare(i)
+
+		switch operator {
+		case pb.Predicate_EQUAL:
+			if c == 0 {
+				return true
+			}
+		case pb.Predicate_LESS:
+			if c < 0 {
+				return true
+			}
+		case pb.Predicate_LESS_OR_EQUAL:
+			if c <= 0 {
+				return true
+			}
+		case pb.Predicate_GREATER:
+			if c > 0 {
+				return true
+			}
+		case pb.Predicate_GREATER_OR_EQUAL:
+			if c >= 0 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func compareFloat(a float64, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareUint(a uint64, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func valueType(predicate *pb.Predicate) string {
+	switch predicate.GetValue().(type) {
+	case *pb.Predicate_Text:
+		return "a text"
+	case *pb.Predicate_Flag:
+		return "a flag"
+	case *pb.Predicate_Number:
+		return "a number"
+	default:
+		return "a memory"
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 
 	"github.com/google/uuid"
@@ -11,8 +13,12 @@ import (
 
 type ImageStore interface {
 	Save(laptopID string, imageType string, imageData bytes.Buffer) (string, error)
+	// DeleteLaptopImages removes all images of the laptop and returns how many were removed
+	DeleteLaptopImages(laptopID string) (int, error)
 }
 
+// DiskImageStore writes images to files of a folder. The file names start with
+// the ID of the laptop, so that the images of a laptop are found again after a restart.
 type DiskImageStore struct{
 	mutex sync.RWMutex
 	imageFolder string
@@ -33,34 +39,99 @@ func NewDiscImageStore(imageFolder string) *DiskImageStore{
 }
 
 func (store *DiskImageStore) Save(laptopID string, imageType string, imageData bytes.Buffer) (string, error){
+	imageID, imagePath, err := writeImageFile(store.imageFolder, laptopID, imageType, imageData)
+	if err != nil{
+		return "", err
+	}
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	store.images[imageID] = &ImageInfo{
+		LaptopID: laptopID,
+		Type: imageType,
+		Path: imagePath,
+	}
+
+	return imageID, nil
+}
+
+// writeImageFile writes the image of the laptop to a new file of the folder,
+// named after the laptop and image IDs, and returns the image ID and the file path
+func writeImageFile(imageFolder string, laptopID string, imageType string, imageData bytes.Buffer) (string, string, error){
+	if strings.ContainsAny(laptopID, `/\`){
+		return "", "", fmt.Errorf("invalid laptop ID %q", laptopID)
+	}
+
 	imageID, err := uuid.NewRandom()
 
 	if (err != nil){
-		return "", err
+		return "", "", err
 	}
 
-	imagePath := fmt.Sprintf("%s/%s%s", store.imageFolder,imageID, imageType)
+	imagePath := fmt.Sprintf("%s/%s%s", imageFolder, imageFilePrefix(laptopID)+imageID.String(), imageType)
 
 	file, err := os.Create(imagePath)
 	if err != nil{
-		return "", err
+		return "", "", err
 	}
-
-	//log.Print(imageData.Len())
+	defer file.Close()
 
 	_, err = imageData.WriteTo(file)
 	if (err != nil){
-		return "", err
+		return "", "", err
+	}
+
+	return imageID.String(), imagePath, nil
+}
+
+// imageFilePrefix returns the start of the names of the image files of the laptop
+func imageFilePrefix(laptopID string) string{
+	return laptopID + "_"
+}
+
+// isImageFileOf reports whether the file name is made of the prefix of the
+// laptop and an image ID, and not of the prefix of another laptop whose ID
+// starts with the same characters
+func isImageFileOf(name string, laptopID string) bool{
+	prefix := imageFilePrefix(laptopID)
+	if !strings.HasPrefix(name, prefix) || len(name) < len(prefix)+len(uuid.Nil.String()){
+		return false
 	}
 
+	_, err := uuid.Parse(name[len(prefix):len(prefix)+len(uuid.Nil.String())])
+	return err == nil
+}
+
+// DeleteLaptopImages removes the image files of the laptop found in the folder,
+// including those saved before the store was created
+func (store *DiskImageStore) DeleteLaptopImages(laptopID string) (int, error){
 	store.mutex.Lock()
 	defer store.mutex.Unlock()
 
-	store.images[imageID.String()] = &ImageInfo{
-		LaptopID: laptopID,
-		Type: imageType,
-		Path: imagePath,
+	entries, err := os.ReadDir(store.imageFolder)
+	if err != nil{
+		return 0, fmt.Errorf("cannot read image folder: %w", err)
 	}
 
-	return imageID.String(), nil
-}
\ No newline at end of file
+	deleted := 0
+	for _, entry := range entries{
+		if entry.IsDir() || !isImageFileOf(entry.Name(), laptopID){
+			continue
+		}
+
+		err := os.Remove(filepath.Join(store.imageFolder, entry.Name()))
+		if err != nil && !os.IsNotExist(err){
+			return deleted, fmt.Errorf("cannot delete image file %s: %w", entry.Name(), err)
+		}
+		deleted++
+	}
+
+	for imageID, info := range store.images{
+		if info.LaptopID == laptopID{
+			delete(store.images, imageID)
+		}
+	}
+
+	return deleted, nil
+}
@@ -6,11 +6,14 @@ import (
 	"gRPC/sample"
 	"gRPC/serializer"
 	"gRPC/service"
+	"io"
 	"net"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestClientCreateLaptop(t *testing.T){
@@ -31,12 +34,15 @@ func TestClientCreateLaptop(t *testing.T){
 	require.NoError(t, err)
 	require.NotNil(t, res)
 	require.Equal(t, expectedID, res.Id)
+	require.NotZero(t, res.Revision)
 
 	// 
 	other, err := laptopStore.Find(res.Id)
 	require.NoError(t, err)
 	require.NotNil(t, other)
+	require.Equal(t, res.Revision, other.Revision)
 
+	laptop.Revision = res.Revision
 	requireSameLaptop(t,laptop, other)
 }
 
@@ -70,3 +76,388 @@ func requireSameLaptop(t *testing.T, a *pb.Laptop, b *pb.Laptop){
 	require.Equal(t, json1, json2)
 }
 
+func TestClientUpdateAndDeleteLaptop(t *testing.T){
+	t.Parallel()
+
+	laptopStore := service.NewInMemoryLaptopStore()
+	laptop := sample.NewLaptop()
+	err := laptopStore.Save(laptop)
+	require.NoError(t, err)
+
+	serverAddress := startTestLaptopServer(t, laptopStore, nil)
+	laptopClient := newTestLaptopClient(t, serverAddress)
+
+	laptop.PriceUsd = 999
+	updateRes, err := laptopClient.UpdateLaptop(context.Background(), &pb.UpdateLaptopRequest{Laptop: laptop})
+	require.NoError(t, err)
+	require.Equal(t, float64(999), updateRes.Laptop.PriceUsd)
+
+	getRes, err := laptopClient.GetLaptop(context.Background(), &pb.GetLaptopRequest{Id: laptop.Id})
+	require.NoError(t, err)
+	requireSameLaptop(t, updateRes.Laptop, getRes.Laptop)
+
+	_, err = laptopClient.DeleteLaptop(context.Background(), &pb.DeleteLaptopRequest{Id: laptop.Id})
+	require.NoError(t, err)
+
+	_, err = laptopClient.GetLaptop(context.Background(), &pb.GetLaptopRequest{Id: laptop.Id})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestClientSearchLaptopPagination(t *testing.T){
+	t.Parallel()
+
+	laptopStore := service.NewInMemoryLaptopStore()
+	for i := 0; i < 5; i++{
+		err := laptopStore.Save(sample.NewLaptop())
+		require.NoError(t, err)
+	}
+
+	serverAddress := startTestLaptopServer(t, laptopStore, nil)
+	laptopClient := newTestLaptopClient(t, serverAddress)
+
+	req := &pb.SearchLaptopRequest{
+		Filter: &pb.Filter{MaxPriceUsd: 5000},
+		PageSize: 2,
+	}
+
+	found := map[string]bool{}
+	pages := 0
+	for {
+		stream, err := laptopClient.SearchLaptop(context.Background(), req)
+		require.NoError(t, err)
+
+		nextPageToken := ""
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF{
+				break
+			}
+			require.NoError(t, err)
+
+			if res.GetNextPageToken() != ""{
+				nextPageToken = res.GetNextPageToken()
+				continue
+			}
+			require.False(t, found[res.GetLaptop().GetId()])
+			found[res.GetLaptop().GetId()] = true
+		}
+
+		pages++
+		if nextPageToken == ""{
+			break
+		}
+		req.PageToken = nextPageToken
+	}
+
+	require.Equal(t, 3, pages)
+	require.Len(t, found, 5)
+
+	req.PageToken = "invalid"
+	stream, err := laptopClient.SearchLaptop(context.Background(), req)
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestClientSearchLaptopQuery(t *testing.T){
+	t.Parallel()
+
+	laptopStore := service.NewInMemoryLaptopStore()
+	dell := sample.NewLaptop()
+	dell.Brand = "Dell"
+	dell.Ram = &pb.Memory{Value: 32, Unit: pb.Memory_GIGABYTE}
+	lenovo := sample.NewLaptop()
+	lenovo.Brand = "Lenovo"
+	for _, laptop := range []*pb.Laptop{dell, lenovo}{
+		err := laptopStore.Save(laptop)
+		require.NoError(t, err)
+	}
+
+	serverAddress := startTestLaptopServer(t, laptopStore, nil)
+	laptopClient := newTestLaptopClient(t, serverAddress)
+
+	stream, err := laptopClient.SearchLaptop(context.Background(), &pb.SearchLaptopRequest{Query: "brand:dell ram>=16GB"})
+	require.NoError(t, err)
+
+	res, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, dell.Id, res.GetLaptop().GetId())
+
+	_, err = stream.Recv()
+	require.Equal(t, io.EOF, err)
+
+	stream, err = laptopClient.SearchLaptop(context.Background(), &pb.SearchLaptopRequest{Query: "brand:dell ram>=16"})
+	require.NoError(t, err)
+
+	_, err = stream.Recv()
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Contains(t, status.Convert(err).Message(), "column 17")
+}
+
+
+func TestClientWatchSearch(t *testing.T){
+	t.Parallel()
+
+	laptopStore := service.NewInMemoryLaptopStore()
+	dell := sample.NewLaptop()
+	dell.Brand = "Dell"
+	lenovo := sample.NewLaptop()
+	lenovo.Brand = "Lenovo"
+	for _, laptop := range []*pb.Laptop{dell, lenovo}{
+		err := laptopStore.Save(laptop)
+		require.NoError(t, err)
+	}
+
+	serverAddress := startTestLaptopServer(t, laptopStore, nil)
+	laptopClient := newTestLaptopClient(t, serverAddress)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	filter := &pb.Filter{Brands: []string{"dell"}, MaxPriceUsd: 10000}
+	stream, err := laptopClient.WatchSearch(ctx, &pb.WatchSearchRequest{Filter: filter})
+	require.NoError(t, err)
+
+	res, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, dell.Id, res.GetLaptop().GetId())
+
+	other := sample.NewLaptop()
+	other.Brand = "Lenovo"
+	err = laptopStore.Save(other)
+	require.NoError(t, err)
+
+	created := sample.NewLaptop()
+	created.Brand = "Dell"
+	err = laptopStore.Save(created)
+	require.NoError(t, err)
+
+	res, err = stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, created.Id, res.GetLaptop().GetId())
+	require.Equal(t, created.Revision, res.GetLaptop().GetRevision())
+
+	lenovo.Brand = "Dell"
+	err = laptopStore.Update(lenovo, 0)
+	require.NoError(t, err)
+
+	res, err = stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, lenovo.Id, res.GetLaptop().GetId())
+	require.Equal(t, "Dell", res.GetLaptop().GetBrand())
+
+	cancel()
+	_, err = stream.Recv()
+	require.Equal(t, codes.Canceled, status.Code(err))
+}
+
+func TestClientWatchLaptopChanges(t *testing.T){
+	t.Parallel()
+
+	laptopStore := service.NewInMemoryLaptopStore()
+	laptop := sample.NewLaptop()
+	err := laptopStore.Save(laptop)
+	require.NoError(t, err)
+
+	serverAddress := startTestLaptopServer(t, laptopStore, nil)
+	laptopClient := newTestLaptopClient(t, serverAddress)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := laptopClient.WatchLaptopChanges(ctx, &pb.WatchLaptopChangesRequest{})
+	require.NoError(t, err)
+
+	res, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), res.GetChange().GetSequence())
+	require.Equal(t, pb.LaptopChange_CREATED, res.GetChange().GetType())
+	require.Equal(t, laptop.Id, res.GetChange().GetAfter().GetId())
+
+	err = laptopStore.Delete(laptop.Id, 0)
+	require.NoError(t, err)
+
+	res, err = stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), res.GetChange().GetSequence())
+	require.Equal(t, pb.LaptopChange_DELETED, res.GetChange().GetType())
+	require.Equal(t, laptop.Id, res.GetChange().GetBefore().GetId())
+
+	resumed, err := laptopClient.WatchLaptopChanges(ctx, &pb.WatchLaptopChangesRequest{AfterSequence: 1})
+	require.NoError(t, err)
+
+	res, err = resumed.Recv()
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), res.GetChange().GetSequence())
+
+	future, err := laptopClient.WatchLaptopChanges(ctx, &pb.WatchLaptopChangesRequest{AfterSequence: 10})
+	require.NoError(t, err)
+
+	_, err = future.Recv()
+	require.Equal(t, codes.OutOfRange, status.Code(err))
+}
+
+func TestClientBatchCreateLaptops(t *testing.T){
+	t.Parallel()
+
+	laptopStore := service.NewInMemoryLaptopStore()
+	existing := sample.NewLaptop()
+	err := laptopStore.Save(existing)
+	require.NoError(t, err)
+
+	serverAddress := startTestLaptopServer(t, laptopStore, nil)
+	laptopClient := newTestLaptopClient(t, serverAddress)
+
+	batchCreate := func(allOrNothing bool, laptops ...*pb.Laptop) []*pb.BatchCreateResult{
+		stream, err := laptopClient.BatchCreateLaptops(context.Background())
+		require.NoError(t, err)
+
+		err = stream.Send(&pb.BatchCreateLaptopsRequest{
+			Data: &pb.BatchCreateLaptopsRequest_Options{Options: &pb.BatchCreateOptions{AllOrNothing: allOrNothing}},
+		})
+		require.NoError(t, err)
+
+		for _, laptop := range laptops{
+			err = stream.Send(&pb.BatchCreateLaptopsRequest{
+				Data: &pb.BatchCreateLaptopsRequest_Laptop{Laptop: laptop},
+			})
+			require.NoError(t, err)
+		}
+
+		res, err := stream.CloseAndRecv()
+		require.NoError(t, err)
+		require.Len(t, res.GetResults(), len(laptops))
+		return res.GetResults()
+	}
+
+	created := sample.NewLaptop()
+	noID := sample.NewLaptop()
+	noID.Id = ""
+	invalid := sample.NewLaptop()
+	invalid.Id = "invalid-uuid"
+
+	negativePrice := sample.NewLaptop()
+	negativePrice.PriceUsd = -1
+
+	results := batchCreate(false, created, existing, noID, invalid, created, negativePrice)
+	require.Equal(t, pb.BatchCreateResult_CREATED, results[0].GetStatus())
+	require.Equal(t, created.Id, results[0].GetId())
+	require.NotZero(t, results[0].GetRevision())
+	require.Equal(t, pb.BatchCreateResult_ALREADY_EXISTS, results[1].GetStatus())
+	require.Equal(t, pb.BatchCreateResult_CREATED, results[2].GetStatus())
+	require.NotEmpty(t, results[2].GetId())
+	require.Equal(t, pb.BatchCreateResult_INVALID_ID, results[3].GetStatus())
+	require.Equal(t, "invalid-uuid", results[3].GetId())
+	require.Equal(t, pb.BatchCreateResult_ALREADY_EXISTS, results[4].GetStatus())
+	require.Equal(t, pb.BatchCreateResult_INVALID_LAPTOP, results[5].GetStatus())
+	require.Contains(t, results[5].GetDescription(), "laptop.price_usd")
+
+	other, err := laptopStore.Find(results[2].GetId())
+	require.NoError(t, err)
+	require.NotNil(t, other)
+
+	rolledBack := sample.NewLaptop()
+	results = batchCreate(true, rolledBack, existing)
+	require.Equal(t, pb.BatchCreateResult_ROLLED_BACK, results[0].GetStatus())
+	require.Zero(t, results[0].GetRevision())
+	require.Equal(t, pb.BatchCreateResult_ALREADY_EXISTS, results[1].GetStatus())
+
+	results = batchCreate(true, rolledBack, invalid)
+	require.Equal(t, pb.BatchCreateResult_ROLLED_BACK, results[0].GetStatus())
+	require.Equal(t, pb.BatchCreateResult_INVALID_ID, results[1].GetStatus())
+
+	other, err = laptopStore.Find(rolledBack.Id)
+	require.NoError(t, err)
+	require.Nil(t, other)
+
+	first, second := sample.NewLaptop(), sample.NewLaptop()
+	results = batchCreate(true, first, second)
+	for _, result := range results{
+		require.Equal(t, pb.BatchCreateResult_CREATED, result.GetStatus())
+	}
+
+	other, err = laptopStore.Find(second.Id)
+	require.NoError(t, err)
+	require.NotNil(t, other)
+}
+
+func TestClientErrorDetails(t *testing.T){
+	t.Parallel()
+
+	laptopStore := service.NewInMemoryLaptopStore()
+	laptop := sample.NewLaptop()
+	err := laptopStore.Save(laptop)
+	require.NoError(t, err)
+
+	serverAddress := startTestLaptopServer(t, laptopStore, service.NewDiscImageStore(t.TempDir()))
+	laptopClient := newTestLaptopClient(t, serverAddress)
+	ctx := context.Background()
+
+	missingID := sample.NewLaptop().Id
+	_, err = laptopClient.GetLaptop(ctx, &pb.GetLaptopRequest{Id: missingID})
+	details := service.ParseErrorDetails(err)
+	require.Equal(t, codes.NotFound, details.Code)
+	require.Equal(t, service.ReasonNotFound, details.Reason())
+	require.Equal(t, service.ErrorDomain, details.Info.GetDomain())
+	require.Equal(t, missingID, details.Resource.GetResourceName())
+	require.Contains(t, details.String(), "laptop "+missingID+" does not exist")
+
+	_, err = laptopClient.DeleteLaptop(ctx, &pb.DeleteLaptopRequest{Id: laptop.Id, ExpectedRevision: laptop.Revision + 1})
+	details = service.ParseErrorDetails(err)
+	require.Equal(t, codes.Aborted, details.Code)
+	require.Equal(t, service.ReasonRevisionMismatch, details.Reason())
+	require.Equal(t, laptop.Id, details.Resource.GetResourceName())
+
+	_, err = laptopClient.GetLaptop(ctx, &pb.GetLaptopRequest{Id: "invalid"})
+	details = service.ParseErrorDetails(err)
+	require.Equal(t, codes.InvalidArgument, details.Code)
+	require.Len(t, details.FieldViolations, 1)
+	require.Equal(t, "id", details.FieldViolations[0].GetField())
+
+	rateStream, err := laptopClient.RateLaptop(ctx)
+	require.NoError(t, err)
+	err = rateStream.Send(&pb.RateLaptopRequest{LaptopId: missingID, Score: 5})
+	require.NoError(t, err)
+	_, err = rateStream.Recv()
+	details = service.ParseErrorDetails(err)
+	require.Equal(t, codes.NotFound, details.Code)
+	require.Equal(t, missingID, details.Resource.GetResourceName())
+
+	uploadImage := func(laptopID string, size int) error{
+		stream, err := laptopClient.UploadImage(ctx)
+		require.NoError(t, err)
+
+		err = stream.Send(&pb.UploadImageRequest{
+			Data: &pb.UploadImageRequest_Info{Info: &pb.ImageInfo{LaptopId: laptopID, ImageType: ".jpg"}},
+		})
+		require.NoError(t, err)
+
+		chunk := make([]byte, 1024)
+		for sent := 0; sent < size; sent += len(chunk){
+			err = stream.Send(&pb.UploadImageRequest{
+				Data: &pb.UploadImageRequest_ChunkData{ChunkData: chunk},
+			})
+			if err != nil{
+				// the server already failed, its error is returned by CloseAndRecv
+				break
+			}
+		}
+
+		_, err = stream.CloseAndRecv()
+		return err
+	}
+
+	err = uploadImage(missingID, 1024)
+	details = service.ParseErrorDetails(err)
+	require.Equal(t, codes.NotFound, details.Code)
+	require.Equal(t, missingID, details.Resource.GetResourceName())
+
+	err = uploadImage(laptop.Id, 2 << 20)
+	details = service.ParseErrorDetails(err)
+	require.Equal(t, codes.ResourceExhausted, details.Code)
+	require.Equal(t, service.ReasonImageTooLarge, details.Reason())
+	require.Equal(t, "1048576", details.Info.GetMetadata()["max_size"])
+
+	err = uploadImage(laptop.Id, 1024)
+	require.NoError(t, err)
+}
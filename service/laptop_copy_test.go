@@ -0,0 +1,142 @@
+package service
+
+import (
+	"gRPC/pb"
+	"gRPC/sample"
+	"testing"
+
+	"github.com/jinzhu/copier"
+)
+
+// copierCopy is how laptops used to be copied, kept to compare with deepCopy
+func copierCopy(laptop *pb.Laptop) (*pb.Laptop, error) {
+	other := &pb.Laptop{}
+	err := copier.Copy(other, laptop)
+	return other, err
+}
+
+func TestDeepCopy(t *testing.T) {
+	t.Parallel()
+
+	laptop := sample.NewLaptop()
+	laptop.Weight = &pb.Laptop_WeightLb{WeightLb: 4.5}
+	other := deepCopy(laptop)
+
+	// copier shared these messages between the laptop and its copy
+	if other.Screen.Resolution == laptop.Screen.Resolution {
+		t.Error("screen resolution is shared")
+	}
+	if other.Gpus[0] == laptop.Gpus[0] || other.Storages[0] == laptop.Storages[0] {
+		t.Error("repeated messages are shared")
+	}
+	if other.Weight == laptop.Weight {
+		t.Error("weight is shared")
+	}
+	if other.UpdatedAt == laptop.UpdatedAt {
+		t.Error("timestamp is shared")
+	}
+}
+
+func BenchmarkDeepCopy(b *testing.B) {
+	laptop := sample.NewLaptop()
+
+	b.Run("copier", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, err := copierCopy(laptop)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("proto.Clone", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			deepCopy(laptop)
+		}
+	})
+}
+
+// BenchmarkInMemoryLaptopStoreSearchAll searches laptops that all match, so
+// that copying the results dominates
+func BenchmarkInMemoryLaptopStoreSearchAll(b *testing.B) {
+	store := NewInMemoryLaptopStore()
+	for i := 0; i < 1000; i++ {
+		err := store.Save(sample.NewLaptop())
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	query := &SearchQuery{Filter: &pb.Filter{MaxPriceUsd: 5000}}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := store.Search(query, func(laptop *pb.Laptop) error {
+			return nil
+		})
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestInMemoryLaptopStoreReadAllocs(t *testing.T) {
+	store := NewInMemoryLaptopStore()
+	laptop := sample.NewLaptop()
+	err := store.Save(laptop)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// found laptops are shared with the store rather than copied
+	allocs := testing.AllocsPerRun(100, func() {
+		_, err := store.Find(laptop.Id)
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("Find allocates %v times, want 0", allocs)
+	}
+
+	for i := 0; i < 99; i++ {
+		err := store.Save(sample.NewLaptop())
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// searching allocates per result, but much less than a copy of each laptop
+	query := &SearchQuery{Filter: &pb.Filter{MaxPriceUsd: 5000}}
+	allocs = testing.AllocsPerRun(10, func() {
+		_, err := store.Search(query, func(*pb.Laptop) error {
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+	if copyAllocs := testing.AllocsPerRun(10, func() { deepCopy(laptop) }); allocs >= 100*copyAllocs {
+		t.Errorf("Search of 100 laptops allocates %v times, copying them would take %v", allocs, 100*copyAllocs)
+	}
+}
+
+func BenchmarkInMemoryLaptopStoreFind(b *testing.B) {
+	store := NewInMemoryLaptopStore()
+	laptop := sample.NewLaptop()
+	err := store.Save(laptop)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := store.Find(laptop.Id)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"gRPC/pb"
+	"math"
+	"sort"
+	"strings"
+)
+
+type indexEntry struct {
+	key float64
+	id  string
+}
+
+// sortedIndex keeps laptop IDs sorted by a numeric key of the laptop
+type sortedIndex struct {
+	key     func(laptop *pb.Laptop) float64
+	entries []indexEntry
+}
+
+func (index *sortedIndex) search(entry indexEntry) int {
+	return sort.Search(len(index.entries), func(i int) bool {
+		other := index.entries[i]
+		return other.key > entry.key || (other.key == entry.key && other.id >= entry.id)
+	})
+}
+
+func (index *sortedIndex) add(laptop *pb.Laptop) {
+	entry := indexEntry{key: index.key(laptop), id: laptop.GetId()}
+
+	i := index.search(entry)
+	index.entries = append(index.entries, indexEntry{})
+	copy(index.entries[i+1:], index.entries[i:])
+	index.entries[i] = entry
+}
+
+// remove drops the laptop, which must be the version that was added
+func (index *sortedIndex) remove(laptop *pb.Laptop) {
+	entry := indexEntry{key: index.key(laptop), id: laptop.GetId()}
+
+	i := index.search(entry)
+	if i < len(index.entries) && index.entries[i] == entry {
+		index.entries = append(index.entries[:i], index.entries[i+1:]...)
+	}
+}
+
+// bounds returns the range of entries with a key between min and max, both included
+func (index *sortedIndex) bounds(min float64, max float64) (int, int) {
+	low := sort.Search(len(index.entries), func(i int) bool {
+		return index.entries[i].key >= min
+	})
+	high := sort.Search(len(index.entries), func(i int) bool {
+		return index.entries[i].key > max
+	})
+
+	if high < low {
+		high = low
+	}
+	return low, high
+}
+
+// laptopIndexes are the secondary indexes of a laptop store
+type laptopIndexes struct {
+	price       *sortedIndex
+	cpuCores    *sortedIndex
+	ram         *sortedIndex
+	releaseYear *sortedIndex
+	// brands maps lower case brands to laptop IDs
+	brands map[string]map[string]bool
+}
+
+func newLaptopIndexes() *laptopIndexes {
+	return &laptopIndexes{
+		price: &sortedIndex{key: func(laptop *pb.Laptop) float64 {
+			return laptop.GetPriceUsd()
+		}},
+		cpuCores: &sortedIndex{key: func(laptop *pb.Laptop) float64 {
+			return float64(laptop.GetCpu().GetNumberCores())
+		}},
+		ram: &sortedIndex{key: func(laptop *pb.Laptop) float64 {
+			return float64(toBit(laptop.GetRam()))
+		}},
+		releaseYear: &sortedIndex{key: func(laptop *pb.Laptop) float64 {
+			return float64(laptop.GetReleaseYear())
+		}},
+		brands: make(map[string]map[string]bool),
+	}
+}
+
+func (indexes *laptopIndexes) sorted() []*sortedIndex {
+	return []*sortedIndex{indexes.price, indexes.cpuCores, indexes.ram, indexes.releaseYear}
+}
+
+func (indexes *laptopIndexes) add(laptop *pb.Laptop) {
+	for _, index := range indexes.sorted() {
+		index.add(laptop)
+	}
+
+	brand := strings.ToLower(laptop.GetBrand())
+	if indexes.brands[brand] == nil {
+		indexes.brands[brand] = make(map[string]bool)
+	}
+	indexes.brands[brand][laptop.GetId()] = true
+}
+
+// remove drops the laptop, which must be the version that was added
+func (indexes *laptopIndexes) remove(laptop *pb.Laptop) {
+	for _, index := range indexes.sorted() {
+		index.remove(laptop)
+	}
+
+	brand := strings.ToLower(laptop.GetBrand())
+	delete(indexes.brands[brand], laptop.GetId())
+	if len(indexes.brands[brand]) == 0 {
+		delete(indexes.brands, brand)
+	}
+}
+
+// candidates returns the IDs of the laptops that may match the filter, using
+// the most selective index. Every laptop matching the filter is among them,
+// but not every candidate matches. The IDs are in no particular order.
+func (indexes *laptopIndexes) candidates(filter *pb.Filter) []string {
+	maxReleaseYear := math.Inf(1)
+	if filter.GetMaxReleaseYear() > 0 {
+		maxReleaseYear = float64(filter.GetMaxReleaseYear())
+	}
+
+	ranges := []struct {
+		index *sortedIndex
+		min   float64
+		max   float64
+	}{
+		{indexes.price, filter.GetMinPriceUsd(), filter.GetMaxPriceUsd()},
+		{indexes.cpuCores, float64(filter.GetMinCpuCores()), math.Inf(1)},
+		{indexes.ram, float64(toBit(filter.GetMinRam())), math.Inf(1)},
+		{indexes.releaseYear, float64(filter.GetMinReleaseYear()), maxReleaseYear},
+	}
+
+	var best *sortedIndex
+	bestLow, bestHigh := 0, 0
+	for _, r := range ranges {
+		low, high := r.index.bounds(r.min, r.max)
+		if best == nil || high-low < bestHigh-bestLow {
+			best, bestLow, bestHigh = r.index, low, high
+		}
+	}
+
+	if len(filter.GetBrands()) > 0 {
+		count := 0
+		for _, brand := range filter.GetBrands() {
+			count += len(indexes.brands[strings.ToLower(brand)])
+		}
+
+		if count < bestHigh-bestLow {
+			ids := make([]string, 0, count)
+			for _, brand := range uniqueLower(filter.GetBrands()) {
+				for id := range indexes.brands[brand] {
+					ids = append(ids, id)
+				}
+			}
+			return ids
+		}
+	}
+
+	ids := make([]string, 0, bestHigh-bestLow)
+	for _, entry := range best.entries[bestLow:bestHigh] {
+		ids = append(ids, entry.id)
+	}
+	return ids
+}
+
+func uniqueLower(values []string) []string {
+	seen := make(map[string]bool)
+	unique := []string{}
+	for _, value := range values {
+		value = strings.ToLower(value)
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+
+	return unique
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"fmt"
+	"gRPC/pb"
+	"gRPC/sample"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newIndexTestStore(t testing.TB, n int) *InMemoryLaptopStore {
+	store := NewInMemoryLaptopStore()
+	for i := 0; i < n; i++ {
+		err := store.Save(sample.NewLaptop())
+		require.NoError(t, err)
+	}
+
+	return store
+}
+
+func randomIndexTestFilter() *pb.Filter {
+	filter := &pb.Filter{
+		MaxPriceUsd: 1500 + rand.Float64()*2000,
+		MinPriceUsd: rand.Float64() * 2000,
+		MinCpuCores: uint32(rand.Intn(8)),
+		MinRam:      &pb.Memory{Value: uint64(rand.Intn(64)), Unit: pb.Memory_GIGABYTE},
+	}
+
+	if rand.Intn(2) == 0 {
+		filter.MinReleaseYear = uint32(2015 + rand.Intn(5))
+		filter.MaxReleaseYear = filter.MinReleaseYear + uint32(rand.Intn(3))
+	}
+
+	if rand.Intn(2) == 0 {
+		filter.Brands = []string{"dell", "APPLE"}[:1+rand.Intn(2)]
+	}
+
+	return filter
+}
+
+func searchIndexTestIDs(t testing.TB, store *InMemoryLaptopStore, query *SearchQuery, useIndexes bool) []string {
+	ids := []string{}
+	_, err := store.search(query, func(laptop *pb.Laptop) error {
+		ids = append(ids, laptop.Id)
+		return nil
+	}, useIndexes)
+	require.NoError(t, err)
+
+	return ids
+}
+
+func TestInMemoryLaptopStoreIndexes(t *testing.T) {
+	t.Parallel()
+
+	store := newIndexTestStore(t, 300)
+
+	// keep the indexes busy with updates and deletes
+	for i, id := range store.order {
+		laptop, err := store.Find(id)
+		require.NoError(t, err)
+
+		switch i % 3 {
+		case 0:
+			other := sample.NewLaptop()
+			other.Id = id
+			err = store.Update(other, 0)
+		case 1:
+			_, err = store.Patch(id, &pb.Laptop{PriceUsd: laptop.PriceUsd + 100, Brand: "Lenovo"}, []string{"price_usd", "brand"}, 0)
+		}
+		require.NoError(t, err)
+	}
+	for _, id := range append([]string{}, store.order[:50]...) {
+		err := store.Delete(id, 0)
+		require.NoError(t, err)
+	}
+
+	for _, index := range store.indexes.sorted() {
+		require.Len(t, index.entries, len(store.data))
+	}
+
+	for i := 0; i < 200; i++ {
+		query := &SearchQuery{
+			Filter:  randomIndexTestFilter(),
+			OrderBy: &pb.OrderBy{Field: pb.OrderBy_Field(rand.Intn(4))},
+		}
+
+		expected := searchIndexTestIDs(t, store, query, false)
+		actual := searchIndexTestIDs(t, store, query, true)
+		require.Equal(t, expected, actual, "filter: %v", query.Filter)
+	}
+}
+
+func BenchmarkInMemoryLaptopStoreSearch(b *testing.B) {
+	store := newIndexTestStore(b, 50000)
+
+	// the filters match few laptops, so that the cost of sorting and sending results does not hide the scan
+	filters := map[string]*pb.Filter{
+		"price":         {MinPriceUsd: 3499, MaxPriceUsd: 3500},
+		"cores_and_ram": {MaxPriceUsd: 5000, MinCpuCores: 8, MinRam: &pb.Memory{Value: 63, Unit: pb.Memory_GIGABYTE}},
+		"brand":         {MaxPriceUsd: 5000, Brands: []string{"Apple"}, MinReleaseYear: 2019, MinCpuCores: 8, MinCpuGhz: 3.4},
+	}
+
+	for name, filter := range filters {
+		for _, useIndexes := range []bool{false, true} {
+			b.Run(fmt.Sprintf("%s/indexes=%v", name, useIndexes), func(b *testing.B) {
+				query := &SearchQuery{Filter: filter}
+				for i := 0; i < b.N; i++ {
+					_, err := store.search(query, func(laptop *pb.Laptop) error {
+						return nil
+					}, useIndexes)
+					if err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// purgeErrors lists the cleanups that failed while purging laptops
+type purgeErrors []error
+
+func (errs purgeErrors) Error() string {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// PurgeDeletedLaptops permanently removes the laptops that were deleted more than
+// retention ago, together with their images and ratings, and returns their IDs.
+// Purged laptops are not returned again, so the images and ratings of every
+// purged laptop are deleted even if some of them fail.
+func (server *LaptopServer) PurgeDeletedLaptops(retention time.Duration) ([]string, error) {
+	purged, err := server.laptopStore.Purge(time.Now().Add(-retention))
+	if err != nil {
+		return nil, fmt.Errorf("cannot purge laptops: %w", err)
+	}
+
+	errs := purgeErrors{}
+	for _, laptopID := range purged {
+		if server.imageStore != nil {
+			_, err = server.imageStore.DeleteLaptopImages(laptopID)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("cannot purge images of laptop %s: %w", laptopID, err))
+			}
+		}
+
+		if server.ratingStore != nil {
+			err = server.ratingStore.Delete(laptopID)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("cannot purge rating of laptop %s: %w", laptopID, err))
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return purged, errs
+	}
+
+	return purged, nil
+}
+
+// RunPurge purges the deleted laptops every interval until the context is done.
+// It returns at once if the interval is not positive, which disables purging.
+func (server *LaptopServer) RunPurge(ctx context.Context, retention time.Duration, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, err := server.PurgeDeletedLaptops(retention)
+			if err != nil {
+				log.Print(err)
+			}
+			if len(purged) > 0 {
+				log.Printf("purged %d deleted laptops", len(purged))
+			}
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"gRPC/pb"
+	"sort"
+)
+
+const poundToKg = 0.45359237
+
+// searchResult is a laptop matching a search together with its position in the results
+type searchResult struct {
+	laptop   *pb.Laptop
+	key      float64
+	sequence uint64
+}
+
+// appliesFilter reports whether laptops must match the filter of the query,
+// which is skipped when it is left out and other criteria are given
+func (query *SearchQuery) appliesFilter() bool {
+	return query.Filter != nil || (query.Expression == nil && query.Text == "")
+}
+
+// matcher returns the predicate selecting the laptops of the query
+func (query *SearchQuery) matcher() (laptopPredicate, error) {
+	predicates := []laptopPredicate{}
+
+	if query.appliesFilter() {
+		filter := query.Filter
+		predicates = append(predicates, func(laptop *pb.Laptop) bool {
+			return isQualified(filter, laptop)
+		})
+	}
+
+	if query.Expression != nil {
+		expression, err := compileExpression(query.Expression)
+		if err != nil {
+			return nil, err
+		}
+		predicates = append(predicates, expression)
+	}
+
+	return func(laptop *pb.Laptop) bool {
+		for _, predicate := range predicates {
+			if !predicate(laptop) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+// ordering returns how the results of the query are sorted.
+// Text searches are sorted by relevance unless another order is given.
+func (query *SearchQuery) ordering() *pb.OrderBy {
+	if query.Text != "" && query.OrderBy.GetField() == pb.OrderBy_DEFAULT {
+		return &pb.OrderBy{Field: pb.OrderBy_RELEVANCE, Descending: true}
+	}
+
+	return query.OrderBy
+}
+
+// sortKey returns the value of the laptop used to sort search results,
+// relevance holds the text search score of each laptop
+func sortKey(laptop *pb.Laptop, field pb.OrderBy_Field, ratings RatingStore, relevance map[string]float64) float64 {
+	switch field {
+	case pb.OrderBy_PRICE_USD:
+		return laptop.GetPriceUsd()
+	case pb.OrderBy_RELEASE_YEAR:
+		return float64(laptop.GetReleaseYear())
+	case pb.OrderBy_CPU_NUMBER_CORES:
+		return float64(laptop.GetCpu().GetNumberCores())
+	case pb.OrderBy_RAM:
+		return float64(toBit(laptop.GetRam()))
+	case pb.OrderBy_WEIGHT:
+		return weightKg(laptop)
+	case pb.OrderBy_AVERAGE_RATING:
+		return averageRating(ratings, laptop.GetId())
+	case pb.OrderBy_RELEVANCE:
+		return relevance[laptop.GetId()]
+	default:
+		return 0
+	}
+}
+
+func weightKg(laptop *pb.Laptop) float64 {
+	switch weight := laptop.GetWeight().(type) {
+	case *pb.Laptop_WeightKg:
+		return weight.WeightKg
+	case *pb.Laptop_WeightLb:
+		return weight.WeightLb * poundToKg
+	default:
+		return 0
+	}
+}
+
+func averageRating(ratings RatingStore, laptopID string) float64 {
+	if ratings == nil {
+		return 0
+	}
+
+	rating, err := ratings.Find(laptopID)
+	if err != nil || rating == nil || rating.Count == 0 {
+		return 0
+	}
+
+	return rating.Sum / float64(rating.Count)
+}
+
+// lessResult orders search results by their key and then by creation sequence,
+// so that results with the same key keep a stable order between pages
+func lessResult(a *searchResult, b *searchResult, orderBy *pb.OrderBy) bool {
+	if a.key != b.key {
+		if orderBy.GetDescending() {
+			return a.key > b.key
+		}
+		return a.key < b.key
+	}
+
+	return a.sequence < b.sequence
+}
+
+// sortSearchResults sorts the results and drops those up to and including the cursor
+func sortSearchResults(results []*searchResult, orderBy *pb.OrderBy, cursor *searchResult) []*searchResult {
+	sort.SliceStable(results, func(i, j int) bool {
+		return lessResult(results[i], results[j], orderBy)
+	})
+
+	if cursor == nil {
+		return results
+	}
+
+	start := sort.Search(len(results), func(i int) bool {
+		return lessResult(cursor, results[i], orderBy)
+	})
+	return results[start:]
+}
+
+// sendSearchResults passes at most pageSize results to found and
+// returns the token of the next page if more results are left
+func sendSearchResults(results []*searchResult, orderBy *pb.OrderBy, pageSize uint32, found func(*pb.Laptop) error) (string, error) {
+	for i, result := range results {
+		if pageSize > 0 && uint32(i) == pageSize {
+			return encodePageToken(orderBy, results[i-1])
+		}
+
+		err := found(result.laptop)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return "", nil
+}
@@ -4,17 +4,25 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"gRPC/pb"
 	"io"
 	"log"
+	"strconv"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 const maxImageSize = 1 << 20
 
+// maxPatchAttempts bounds how many times a patch is retried when the laptop changes concurrently
+const maxPatchAttempts = 3
+
 type LaptopServer struct {
 	laptopStore LaptopStore
 	imageStore ImageStore
@@ -31,44 +39,228 @@ func NewLaptopServer(laptopStore LaptopStore, imageStore ImageStore, ratingStore
 
 func (server *LaptopServer) CreateLaptop(ctx context.Context,req *pb.CreateLatopRequest) (*pb.CreateLatopResponse, error){
 	laptop := req.GetLatop()
+	if laptop == nil{
+		return nil, logError(invalidFieldError("latop", "is required"))
+	}
 	log.Printf("receive a create-latop req with id: %s", laptop.Id)
+	err := prepareLaptopID("latop.id", laptop)
+	if err != nil{
+		return nil, logError(err)
+	}
+
+	violations := validateLaptop("latop", laptop)
+	if len(violations) > 0{
+		return nil, logError(badRequestError(violations))
+	}
+
+	// the laptop gets its update time and revision below, so work on a clone in
+	// case an in-process caller passes a laptop it read from the store, which is shared
+	laptop = proto.Clone(laptop).(*pb.Laptop)
+
+	created := true
+	if req.GetUpsert(){
+		laptop.UpdatedAt = ptypes.TimestampNow()
+		created, err = server.laptopStore.Upsert(laptop)
+	} else {
+		err = server.laptopStore.Save(laptop)
+	}
+	if err != nil{
+		return nil, logError(laptopStoreError(err, laptop.Id, "save"))
+	}
+
+	log.Printf("saved laptop with id %s", laptop.Id)
+
+	res := &pb.CreateLatopResponse{
+		Id : laptop.Id,
+		Revision: laptop.Revision,
+		Replaced: !created,
+	}
+
+	return res, nil
+}
+
+// prepareLaptopID checks the ID of a laptop to create, or generates one if it is empty.
+// field is the path of the ID in the request.
+func prepareLaptopID(field string, laptop *pb.Laptop) error{
 	if (len(laptop.Id) > 0){
-		_, err := uuid.Parse(laptop.Id)
-		if err != nil{
-			return nil, status.Errorf(codes.InvalidArgument, "latop ID is not a valid UUID")
-		}
+		return validateLaptopID(field, laptop.Id)
 	} else {
 		id, err := uuid.NewRandom()
 		if err != nil{
-			return nil, status.Errorf(codes.Internal, "cannot generate a new laptop ID: %v", err)
+			return internalError(ReasonStorageFailure, "cannot generate a new laptop ID: %v", err)
 		}
 		laptop.Id = id.String()
 	}
 
-	err := server.laptopStore.Save(laptop)
+	return nil
+}
+
+// validateLaptopID checks that the laptop ID at the given path of the request is a UUID
+func validateLaptopID(field string, laptopID string) error{
+	_, err := uuid.Parse(laptopID)
 	if err != nil{
-		code := codes.Internal
-		if (errors.Is(err, ErrAlreadyExists)){
-			code = codes.AlreadyExists
+		return invalidFieldError(field, "is not a valid UUID")
+	}
+
+	return nil
+}
+
+func (server *LaptopServer) BatchCreateLaptops(stream pb.LaptopService_BatchCreateLaptopsServer) error{
+	allOrNothing := false
+	results := []*pb.BatchCreateResult{}
+	// laptops of an all-or-nothing batch are saved together at the end
+	pending := []*pb.Laptop{}
+	pendingResults := []*pb.BatchCreateResult{}
+
+	for i := 0; ; i++{
+		err := contextError(stream.Context())
+		if err != nil{
+			return err
+		}
+
+		req, err := stream.Recv()
+		if err == io.EOF{
+			break
+		}
+		if err != nil{
+			return logError(internalError(ReasonStreamFailure, "cannot receive laptop: %v", err))
 		}
 
-		return nil, status.Errorf(code, "cannot save latop to the store")
+		switch data := req.GetData().(type){
+		case *pb.BatchCreateLaptopsRequest_Options:
+			if i > 0{
+				return logError(invalidFieldError("options", "must be sent before the laptops"))
+			}
+			allOrNothing = data.Options.GetAllOrNothing()
+		case *pb.BatchCreateLaptopsRequest_Laptop:
+			laptop := data.Laptop
+			result := &pb.BatchCreateResult{Id: laptop.GetId()}
+			results = append(results, result)
+
+			err = prepareLaptopID("laptop.id", laptop)
+			if status.Code(err) == codes.InvalidArgument{
+				result.Status = pb.BatchCreateResult_INVALID_ID
+				result.Description = status.Convert(err).Message()
+				continue
+			}
+			if err != nil{
+				return logError(err)
+			}
+			result.Id = laptop.GetId()
+
+			violations := validateLaptop("laptop", laptop)
+			if len(violations) > 0{
+				result.Status = pb.BatchCreateResult_INVALID_LAPTOP
+				result.Description = status.Convert(badRequestError(violations)).Message()
+				continue
+			}
+
+			if allOrNothing{
+				pending = append(pending, laptop)
+				pendingResults = append(pendingResults, result)
+				continue
+			}
+
+			err = server.laptopStore.Save(laptop)
+			err = setBatchCreateResult(result, laptop, err)
+			if err != nil{
+				return logError(laptopStoreError(err, laptop.GetId(), "save"))
+			}
+		default:
+			return logError(invalidFieldError("data", "request %d has no options or laptop", i))
+		}
 	}
 
-	log.Printf("saved laptop with id %s", laptop.Id)
+	if allOrNothing{
+		err := server.saveAll(pending, pendingResults, len(pending) < len(results))
+		if err != nil{
+			return err
+		}
+	}
 
-	res := &pb.CreateLatopResponse{
-		Id : laptop.Id,
+	log.Printf("batch created %d laptops", len(results))
+
+	err := stream.SendAndClose(&pb.BatchCreateLaptopsResponse{Results: results})
+	if err != nil{
+		return logError(internalError(ReasonStreamFailure, "cannot send response: %v", err))
 	}
 
-	return res, nil
+	return nil
+}
+
+// saveAll saves the laptops of an all-or-nothing batch and sets their results.
+// If failed is set, another laptop of the batch already failed and nothing is saved.
+func (server *LaptopServer) saveAll(laptops []*pb.Laptop, results []*pb.BatchCreateResult, failed bool) error{
+	errs := make([]error, len(laptops))
+	if !failed{
+		errs = server.laptopStore.SaveAll(laptops)
+	}
+
+	for i, err := range errs{
+		if err != nil{
+			failed = true
+		}
+
+		err = setBatchCreateResult(results[i], laptops[i], err)
+		if err != nil{
+			return logError(laptopStoreError(err, laptops[i].GetId(), "save"))
+		}
+	}
+
+	if failed{
+		for _, result := range results{
+			if result.Status == pb.BatchCreateResult_CREATED{
+				result.Status = pb.BatchCreateResult_ROLLED_BACK
+				result.Revision = 0
+			}
+		}
+	}
+
+	return nil
+}
+
+// setBatchCreateResult sets the result of saving the laptop with the given error,
+// which is returned back if it is not expected
+func setBatchCreateResult(result *pb.BatchCreateResult, laptop *pb.Laptop, err error) error{
+	switch{
+	case err == nil:
+		result.Status = pb.BatchCreateResult_CREATED
+		result.Revision = laptop.GetRevision()
+	case errors.Is(err, ErrAlreadyExists):
+		result.Status = pb.BatchCreateResult_ALREADY_EXISTS
+	default:
+		return err
+	}
+
+	return nil
 }
 
 func (server *LaptopServer) SearchLaptop(in *pb.SearchLaptopRequest, stream pb.LaptopService_SearchLaptopServer) error{
 	filter := in.GetFilter()
 	log.Printf("Filter received %v", filter)
 
-	err := server.laptopStore.Search(filter, func(laptop *pb.Laptop) error {
+	expression := in.GetExpression()
+	if in.GetQuery() != ""{
+		parsed, err := parseQuery(in.GetQuery())
+		if err != nil{
+			return logError(invalidFieldError("query", "%v", err))
+		}
+
+		expression = combineExpressions(expression, parsed)
+	}
+
+	query := &SearchQuery{
+		Filter: filter,
+		Expression: expression,
+		Text: in.GetText(),
+		OrderBy: in.GetOrderBy(),
+		PageSize: in.GetPageSize(),
+		PageToken: in.GetPageToken(),
+		ShowDeleted: in.GetShowDeleted(),
+		Ratings: server.ratingStore,
+	}
+
+	nextPageToken, err := server.laptopStore.Search(query, func(laptop *pb.Laptop) error {
 		res := &pb.SearchLaptopResponse{
 			Laptop: laptop,
 		}
@@ -83,7 +275,18 @@ func (server *LaptopServer) SearchLaptop(in *pb.SearchLaptopRequest, stream pb.L
 	})
 
 	if (err != nil){
-		return err
+		return logError(searchError(stream.Context(), err))
+	}
+
+	if nextPageToken != ""{
+		res := &pb.SearchLaptopResponse{
+			NextPageToken: nextPageToken,
+		}
+
+		err = stream.Send(res)
+		if err != nil{
+			return logError(internalError(ReasonStreamFailure, "cannot send next page token: %v", err))
+		}
 	}
 
 	return nil
@@ -92,28 +295,39 @@ func (server *LaptopServer) SearchLaptop(in *pb.SearchLaptopRequest, stream pb.L
 func (server *LaptopServer) UploadImage(stream pb.LaptopService_UploadImageServer) error{
 	req, err := stream.Recv()
 	if err != nil{
-		log.Print("cannot receive info", err)
-		return status.Error(codes.Unknown, "cannot receive info")
+		return logError(internalError(ReasonStreamFailure, "cannot receive image info: %v", err))
 	}
 	laptopID := req.GetInfo().GetLaptopId()
 	imageType := req.GetInfo().GetImageType()
 	log.Printf("received %s %s",laptopID, imageType)
 
+	if req.GetInfo() == nil{
+		return logError(invalidFieldError("info", "must be sent before the image data"))
+	}
+
+	err = validateLaptopID("info.laptop_id", laptopID)
+	if err != nil{
+		return logError(err)
+	}
+
 	laptop, err := server.laptopStore.Find(laptopID)
 	if err != nil {
-		log.Print("not founded laptop")
-		return status.Error(codes.Unknown, "laptop not founded")
+		return logError(laptopStoreError(err, laptopID, "find"))
 	}
 
 	if laptop == nil{
-		log.Print("laptop null")
-		return status.Error(codes.Unknown, "laptop null")
+		return logError(notFoundError(laptopResource, laptopID))
 	}
 
 	imageData := bytes.Buffer{}
 	imageSize := 0
 	
 	for {
+		err := contextError(stream.Context())
+		if err != nil{
+			return err
+		}
+
 		log.Print("waiting to receive more data")
 
 		req, err := stream.Recv()
@@ -122,8 +336,7 @@ func (server *LaptopServer) UploadImage(stream pb.LaptopService_UploadImageServe
 			break
 		}
 		if err != nil{
-			log.Print("loop receiving failed")
-			return status.Error(codes.Unknown, "loop receiving failed")
+			return logError(internalError(ReasonStreamFailure, "cannot receive chunk data: %v", err))
 		}
 
 		chunk := req.GetChunkData()
@@ -131,19 +344,26 @@ func (server *LaptopServer) UploadImage(stream pb.LaptopService_UploadImageServe
 
 		imageSize += size
 		if imageSize > maxImageSize{
-			return status.Error(codes.InvalidArgument, "too big")
+			st := status.Newf(codes.ResourceExhausted, "image is too large: %d > %d bytes", imageSize, maxImageSize)
+			return logError(withDetails(st,
+				errorInfo(ReasonImageTooLarge, map[string]string{"max_size": strconv.Itoa(maxImageSize)}),
+				&errdetails.ResourceInfo{
+					ResourceType: imageResource,
+					ResourceName: laptopID,
+					Description: fmt.Sprintf("images are limited to %d bytes", maxImageSize),
+				},
+			))
 		}
 
 		_, err = imageData.Write(chunk)
 		if (err != nil){
-			return status.Error(codes.InvalidArgument, "cannot write chunk to buffer")
+			return logError(internalError(ReasonStorageFailure, "cannot write chunk data: %v", err))
 		}
 	}
 
 	imageID, err := server.imageStore.Save(laptopID, imageType, imageData)
 	if err != nil{
-		log.Print("cannot save image")
-		return status.Error(codes.InvalidArgument, "save failed")
+		return logError(internalError(ReasonStorageFailure, "cannot save image to the store: %v", err))
 	}
 
 	res := &pb.UploadImageResponse{
@@ -153,8 +373,7 @@ func (server *LaptopServer) UploadImage(stream pb.LaptopService_UploadImageServe
 
 	err = stream.SendAndClose(res)
 	if err != nil{
-		log.Print("cannot send res")
-		return status.Error(codes.InvalidArgument, "can not send res")
+		return logError(internalError(ReasonStreamFailure, "cannot send response: %v", err))
 	}
 
 	log.Printf("saved image")
@@ -175,28 +394,28 @@ func (server *LaptopServer) RateLaptop(stream pb.LaptopService_RateLaptopServer)
 		}
 
 		if err != nil{
-			return logError(status.Errorf(codes.Unknown, "cannot receive stram %v", err))
+			return logError(internalError(ReasonStreamFailure, "cannot receive stream request: %v", err))
 		}
 
 		laptopID := req.GetLaptopId()
 		score := req.GetScore()
 
 
-		log.Printf("received a rate-laptop request", laptopID, score)
+		log.Printf("received a rate-laptop request with id: %s, score: %.2f", laptopID, score)
 
 		found, err := server.laptopStore.Find(laptopID)
 		if err != nil{
-			return logError(status.Errorf(codes.Internal, "Error %v", err))
+			return logError(laptopStoreError(err, laptopID, "find"))
 		}
 
 		if found == nil{
-			return logError(status.Errorf(codes.Internal, "Error laptop does not exist"))
+			return logError(notFoundError(laptopResource, laptopID))
 		}
 
 		rating, err := server.ratingStore.Add(laptopID, score)
 
 		if err != nil{
-			return logError(status.Errorf(codes.Internal, "Error can not add rating"))
+			return logError(internalError(ReasonStorageFailure, "cannot add rating to the store: %v", err))
 		}
 
 		res := &pb.RateLaptopRespsonse{
@@ -208,13 +427,307 @@ func (server *LaptopServer) RateLaptop(stream pb.LaptopService_RateLaptopServer)
 		err = stream.Send(res)
 
 		if err != nil{
-			return logError(status.Errorf(codes.Internal, "Error cannot send res on stream"))
+			return logError(internalError(ReasonStreamFailure, "cannot send stream response: %v", err))
 		}
 	}
 	
 	return nil
 }
 
+func (server *LaptopServer) GetLaptop(ctx context.Context, req *pb.GetLaptopRequest) (*pb.GetLaptopResponse, error){
+	laptopID := req.GetId()
+	log.Printf("receive a get-laptop req with id: %s", laptopID)
+
+	err := validateLaptopID("id", laptopID)
+	if err != nil{
+		return nil, logError(err)
+	}
+
+	laptop, err := server.laptopStore.Find(laptopID)
+	if err != nil{
+		return nil, logError(laptopStoreError(err, laptopID, "find"))
+	}
+
+	if laptop == nil{
+		return nil, logError(notFoundError(laptopResource, laptopID))
+	}
+
+	res := &pb.GetLaptopResponse{
+		Laptop: laptop,
+	}
+
+	return res, nil
+}
+
+func (server *LaptopServer) UpdateLaptop(ctx context.Context, req *pb.UpdateLaptopRequest) (*pb.UpdateLaptopResponse, error){
+	laptop := req.GetLaptop()
+	if laptop == nil{
+		return nil, logError(invalidFieldError("laptop", "is required"))
+	}
+	log.Printf("receive an update-laptop req with id: %s", laptop.Id)
+
+	// the request laptop may be shared with the store, as in CreateLaptop
+	laptop = proto.Clone(laptop).(*pb.Laptop)
+
+	err := validateLaptopID("laptop.id", laptop.Id)
+	if err != nil{
+		return nil, logError(err)
+	}
+
+	violations := validateLaptop("laptop", laptop)
+	if len(violations) > 0{
+		return nil, logError(badRequestError(violations))
+	}
+
+	laptop.UpdatedAt = ptypes.TimestampNow()
+
+	err = server.laptopStore.Update(laptop, req.GetExpectedRevision())
+	if err != nil{
+		return nil, logError(laptopStoreError(err, laptop.Id, "update"))
+	}
+
+	log.Printf("updated laptop with id %s", laptop.Id)
+
+	res := &pb.UpdateLaptopResponse{
+		Laptop: laptop,
+	}
+
+	return res, nil
+}
+
+func (server *LaptopServer) PatchLaptop(ctx context.Context, req *pb.PatchLaptopRequest) (*pb.PatchLaptopResponse, error){
+	patch := req.GetLaptop()
+	if patch == nil{
+		return nil, logError(invalidFieldError("laptop", "is required"))
+	}
+	log.Printf("receive a patch-laptop req with id: %s, mask: %v", patch.Id, req.GetUpdateMask().GetPaths())
+
+	err := validateLaptopID("laptop.id", patch.Id)
+	if err != nil{
+		return nil, logError(err)
+	}
+
+	paths := req.GetUpdateMask().GetPaths()
+	err = validateFieldMask(patch, paths)
+	if err != nil{
+		return nil, logError(invalidFieldError("update_mask", "%v", err))
+	}
+
+	patch.UpdatedAt = ptypes.TimestampNow()
+	paths = append(paths, "updated_at")
+
+	laptop, err := server.patchLaptop(patch, paths, req.GetExpectedRevision())
+	if status.Code(err) == codes.InvalidArgument{
+		return nil, logError(err)
+	}
+	if err != nil{
+		return nil, logError(laptopStoreError(err, patch.Id, "patch"))
+	}
+
+	log.Printf("patched laptop with id %s", laptop.Id)
+
+	res := &pb.PatchLaptopResponse{
+		Laptop: laptop,
+	}
+
+	return res, nil
+}
+
+// patchLaptop validates the laptop the patch results in before storing it. Without an
+// expected revision, the patch is retried if the laptop changes after it was validated.
+func (server *LaptopServer) patchLaptop(patch *pb.Laptop, paths []string, expectedRevision uint64) (*pb.Laptop, error){
+	for attempt := 1; ; attempt++{
+		laptop, err := server.laptopStore.Find(patch.Id)
+		if err != nil{
+			return nil, err
+		}
+		if laptop == nil{
+			return nil, ErrNotFound
+		}
+
+		revision := expectedRevision
+		if revision == 0{
+			revision = laptop.Revision
+		}
+
+		// the found laptop is shared with the store, so the patch is validated on a clone
+		laptop = proto.Clone(laptop).(*pb.Laptop)
+		err = applyFieldMask(laptop, patch, paths)
+		if err != nil{
+			return nil, err
+		}
+
+		violations := validateLaptop("laptop", laptop)
+		if len(violations) > 0{
+			return nil, badRequestError(violations)
+		}
+
+		laptop, err = server.laptopStore.Patch(patch.Id, patch, paths, revision)
+		if errors.Is(err, ErrRevisionMismatch) && expectedRevision == 0 && attempt < maxPatchAttempts{
+			continue
+		}
+
+		return laptop, err
+	}
+}
+
+func (server *LaptopServer) DeleteLaptop(ctx context.Context, req *pb.DeleteLaptopRequest) (*pb.DeleteLaptopResponse, error){
+	laptopID := req.GetId()
+	log.Printf("receive a delete-laptop req with id: %s", laptopID)
+
+	err := validateLaptopID("id", laptopID)
+	if err != nil{
+		return nil, logError(err)
+	}
+
+	err = server.laptopStore.Delete(laptopID, req.GetExpectedRevision())
+	if err != nil{
+		return nil, logError(laptopStoreError(err, laptopID, "delete"))
+	}
+
+	log.Printf("deleted laptop with id %s", laptopID)
+
+	res := &pb.DeleteLaptopResponse{
+		Id: laptopID,
+	}
+
+	return res, nil
+}
+
+func (server *LaptopServer) UndeleteLaptop(ctx context.Context, req *pb.UndeleteLaptopRequest) (*pb.UndeleteLaptopResponse, error){
+	laptopID := req.GetId()
+	log.Printf("receive an undelete-laptop req with id: %s", laptopID)
+
+	err := validateLaptopID("id", laptopID)
+	if err != nil{
+		return nil, logError(err)
+	}
+
+	laptop, err := server.laptopStore.Undelete(laptopID, req.GetExpectedRevision())
+	if err != nil{
+		return nil, logError(laptopStoreError(err, laptopID, "undelete"))
+	}
+
+	log.Printf("undeleted laptop with id %s", laptopID)
+
+	res := &pb.UndeleteLaptopResponse{
+		Laptop: laptop,
+	}
+
+	return res, nil
+}
+
+func (server *LaptopServer) SearchFacets(ctx context.Context, req *pb.SearchFacetsRequest) (*pb.SearchFacetsResponse, error){
+	filter := req.GetFilter()
+	log.Printf("receive a search-facets req with filter: %v", filter)
+
+	widths := HistogramWidths{
+		PriceUsd: req.GetPriceUsdBucketWidth(),
+		RamGb: req.GetRamGbBucketWidth(),
+		ReleaseYear: float64(req.GetReleaseYearBucketWidth()),
+	}
+
+	violations := []*errdetails.BadRequest_FieldViolation{}
+	if !validBucketWidth(widths.PriceUsd){
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: "price_usd_bucket_width", Description: fmt.Sprintf("must be a finite number not less than %g", minBucketWidth)})
+	}
+	if !validBucketWidth(widths.RamGb){
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: "ram_gb_bucket_width", Description: fmt.Sprintf("must be a finite number not less than %g", minBucketWidth)})
+	}
+	if len(violations) > 0{
+		return nil, logError(badRequestError(violations))
+	}
+
+	res, err := server.laptopStore.Facets(filter, widths)
+	if err != nil{
+		return nil, logError(internalError(ReasonStorageFailure, "cannot compute facets: %v", err))
+	}
+
+	return res, nil
+}
+
+func (server *LaptopServer) WatchSearch(req *pb.WatchSearchRequest, stream pb.LaptopService_WatchSearchServer) error{
+	filter := req.GetFilter()
+	log.Printf("receive a watch-search req with filter: %v", filter)
+
+	// start watching before searching so that no write is missed in between
+	watch := server.laptopStore.Watch()
+	defer watch.Close()
+
+	query := &SearchQuery{
+		Filter: filter,
+	}
+
+	match, err := query.matcher()
+	if err != nil{
+		return logError(invalidFieldError("filter", "%v", err))
+	}
+
+	// revisions keeps the last revision sent for each laptop, the watch may
+	// deliver writes that the search already saw
+	revisions := make(map[string]uint64)
+	send := func(laptop *pb.Laptop) error {
+		if laptop.GetRevision() <= revisions[laptop.GetId()]{
+			return nil
+		}
+		revisions[laptop.GetId()] = laptop.GetRevision()
+
+		return stream.Send(&pb.WatchSearchResponse{Laptop: laptop})
+	}
+
+	_, err = server.laptopStore.Search(query, send)
+	if err != nil{
+		return logError(searchError(stream.Context(), err))
+	}
+
+	ctx := stream.Context()
+	for{
+		laptop, err := watch.Next(ctx)
+		if err != nil{
+			if errors.Is(err, ErrWatchOverflow){
+				return logError(reasonError(codes.Aborted, ReasonWatchOverflow, nil, "%v", err))
+			}
+			return contextError(ctx)
+		}
+
+		if !match(laptop){
+			continue
+		}
+
+		err = send(laptop)
+		if err != nil{
+			return logError(internalError(ReasonStreamFailure, "cannot send laptop: %v", err))
+		}
+		log.Printf("Watched laptop with id: %s", laptop.Id)
+	}
+}
+
+func (server *LaptopServer) WatchLaptopChanges(req *pb.WatchLaptopChangesRequest, stream pb.LaptopService_WatchLaptopChangesServer) error{
+	log.Printf("receive a watch-changes req after sequence: %d", req.GetAfterSequence())
+
+	watch, err := server.laptopStore.WatchChanges(req.GetAfterSequence())
+	if err != nil{
+		return logError(changesError(err))
+	}
+	defer watch.Close()
+
+	ctx := stream.Context()
+	for{
+		change, err := watch.Next(ctx)
+		if err != nil{
+			if errors.Is(err, ErrChangesTruncated){
+				return logError(changesError(err))
+			}
+			return contextError(ctx)
+		}
+
+		err = stream.Send(&pb.WatchLaptopChangesResponse{Change: change})
+		if err != nil{
+			return logError(internalError(ReasonStreamFailure, "cannot send change: %v", err))
+		}
+	}
+}
+
 func contextError(ctx context.Context) error {
 	switch ctx.Err() {
 	case context.Canceled:
@@ -4,27 +4,119 @@ import (
 	"errors"
 	"fmt"
 	"gRPC/pb"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 
-	"github.com/jinzhu/copier"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 var ErrAlreadyExists = errors.New("record already exists")
+var ErrNotFound = errors.New("record not found")
+var ErrRevisionMismatch = errors.New("record revision does not match")
+var ErrNotDeleted = errors.New("record is not deleted")
 
+// LaptopStore is an interface to store laptops.
+// The store keeps its own copies of the laptops it is given. The laptops it
+// returns are read-only: they may be shared with the store and with other
+// callers, so they must be cloned before being modified or written back.
+// Every write assigns a new revision to the stored laptop; Save and Update also
+// set it on the given laptop. Writes that take an expected revision fail with
+// ErrRevisionMismatch if it is not zero and differs from the stored one.
 type LaptopStore interface {
 	Save(laptop *pb.Laptop) error
+	// SaveAll saves either all the laptops or none of them. It returns one error
+	// per laptop, which is ErrAlreadyExists if its ID is taken in the store or by
+	// an earlier laptop of the batch. Nothing is saved if any error is not nil.
+	SaveAll(laptops []*pb.Laptop) []error
 	Find (ID string) (*pb.Laptop, error)
-	Search (filter *pb.Filter, found func(*pb.Laptop) error) error
+	Update(laptop *pb.Laptop, expectedRevision uint64) error
+	// Upsert saves the laptop or replaces the stored one with the same ID,
+	// reporting whether it was created
+	Upsert(laptop *pb.Laptop) (bool, error)
+	Patch(ID string, patch *pb.Laptop, paths []string, expectedRevision uint64) (*pb.Laptop, error)
+	// Delete marks the laptop as deleted. Deleted laptops are treated as missing,
+	// except by searches that show them, until they are restored or purged.
+	// Their IDs cannot be reused by Save. The DeletedAt of the laptops given to
+	// the other writes is ignored, only Delete and Undelete change it.
+	Delete(ID string, expectedRevision uint64) error
+	// Undelete restores a deleted laptop, it fails with ErrNotDeleted if the laptop is not deleted
+	Undelete(ID string, expectedRevision uint64) (*pb.Laptop, error)
+	// Purge permanently removes the laptops deleted before the given time and returns their IDs
+	Purge(deletedBefore time.Time) ([]string, error)
+	Search (query *SearchQuery, found func(*pb.Laptop) error) (string, error)
+	Facets(filter *pb.Filter, widths HistogramWidths) (*pb.SearchFacetsResponse, error)
+	// Watch starts watching the laptops that are created or updated from now on
+	Watch() LaptopWatch
+	// WatchChanges starts watching the changes with a sequence greater than
+	// afterSequence, which may be 0 to start from the first change. It returns
+	// ErrChangesTruncated if those changes are no longer kept. The changes are
+	// only kept in memory, so the sequences of a persistent store start again
+	// from 1 once it is reopened: a client that watched it before must list
+	// the laptops again instead of resuming from the last sequence it saw.
+	WatchChanges(afterSequence uint64) (LaptopChangeWatch, error)
 }
 
+// SearchQuery selects the laptops returned by LaptopStore.Search.
+// A laptop must match both the Filter and the Expression; the Filter may be
+// left out when an Expression or Text is given. If Text is set, only laptops
+// whose brand, name, CPU or GPU names contain one of its words are returned.
+// Laptops are returned sorted by OrderBy, by default by text relevance for
+// text searches and otherwise in the order they were created. If PageSize is set, at most PageSize laptops are returned
+// together with a token for the next page, which is empty when there are no
+// more results.
+type SearchQuery struct {
+	Filter     *pb.Filter
+	Expression *pb.FilterExpression
+	Text       string
+	OrderBy    *pb.OrderBy
+	PageSize   uint32
+	PageToken  string
+	// ShowDeleted includes deleted laptops in the results
+	ShowDeleted bool
+
+	// Ratings is used to sort laptops by their average rating
+	Ratings RatingStore
+}
+
+// InMemoryLaptopStore keeps laptops in memory as immutable snapshots. Stored
+// laptops are never modified: a write stores a new laptop in place of the old
+// one, so reads hand out the stored laptops without copying them and a laptop
+// that was read keeps its content while the store changes.
 type InMemoryLaptopStore struct{
 	mutex sync.RWMutex
 	data map[string]*pb.Laptop
+	revision uint64
+
+	// order keeps laptop IDs sorted by the sequence in which they were created
+	order []string
+	sequences map[string]uint64
+	sequence uint64
+
+	textIndex *textIndex
+	indexes *laptopIndexes
+
+	changes *changeLog
+
+	// persister, if set, saves every write before it is stored in memory. The
+	// writes must then be serialized by a persistentLaptopStore.
+	persister laptopPersister
 }
 
 func NewInMemoryLaptopStore() *InMemoryLaptopStore{
+	return NewInMemoryLaptopStoreWithChangeLog(defaultChangeLogSize)
+}
+
+// NewInMemoryLaptopStoreWithChangeLog returns a store keeping the given number of latest changes
+func NewInMemoryLaptopStoreWithChangeLog(changeLogSize int) *InMemoryLaptopStore{
 	return &InMemoryLaptopStore{
 		data: make(map[string]*pb.Laptop),
+		sequences: make(map[string]uint64),
+		textIndex: newTextIndex(),
+		indexes: newLaptopIndexes(),
+		changes: newChangeLog(changeLogSize),
 	}
 }
 
@@ -36,45 +128,465 @@ func (store *InMemoryLaptopStore) Save(laptop *pb.Laptop) error{
 		return ErrAlreadyExists
 	}
 
-	// deep copy
-	other, err := deepCopy(laptop)
-	if (err != nil){
-		return fmt.Errorf("cannot copy data")
+	return store.save(laptop)
+}
+
+func (store *InMemoryLaptopStore) SaveAll(laptops []*pb.Laptop) []error{
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	errs := make([]error, len(laptops))
+	failed := false
+	ids := make(map[string]bool)
+	others := make([]*pb.Laptop, len(laptops))
+	for i, laptop := range laptops{
+		if store.data[laptop.Id] != nil || ids[laptop.Id]{
+			errs[i] = ErrAlreadyExists
+			failed = true
+			continue
+		}
+		ids[laptop.Id] = true
+
+		others[i] = writtenCopy(laptop)
+		others[i].Revision = store.revision + uint64(i) + 1
 	}
-	
-	store.data[other.Id] = other
+
+	if failed{
+		return errs
+	}
+
+	err := store.persist(others...)
+	if err != nil{
+		for i := range errs{
+			errs[i] = err
+		}
+		return errs
+	}
+
+	for i, laptop := range laptops{
+		store.insert(laptop, others[i])
+	}
+	return errs
+}
+
+// save stores a copy of the laptop, whose ID must not be taken. The mutex must be locked.
+func (store *InMemoryLaptopStore) save(laptop *pb.Laptop) error{
+	other := writtenCopy(laptop)
+	other.Revision = store.revision + 1
+
+	err := store.persist(other)
+	if err != nil{
+		return err
+	}
+
+	store.insert(laptop, other)
 	return nil
 }
 
+// insert adds other, the copy of the laptop holding its new revision, to the store
+func (store *InMemoryLaptopStore) insert(laptop *pb.Laptop, other *pb.Laptop){
+	store.revision = other.Revision
+	laptop.Revision = other.Revision
+
+	store.data[other.Id] = other
+
+	store.sequence++
+	store.sequences[other.Id] = store.sequence
+	store.order = append(store.order, other.Id)
+
+	store.textIndex.add(other)
+	store.indexes.add(other)
+	store.changes.append(pb.LaptopChange_CREATED, nil, other)
+}
+
 func (store *InMemoryLaptopStore) Find(ID string) (*pb.Laptop, error){
 	store.mutex.RLock()
 	defer store.mutex.RUnlock()
 
 	laptop := store.data[ID]
-	if (laptop == nil){
+	if (laptop == nil || laptop.DeletedAt != nil){
 		return nil, nil
 	}
 
-	return deepCopy(laptop)
+	return laptop, nil
 }
 
-func (store *InMemoryLaptopStore) Search(filter *pb.Filter, found func(*pb.Laptop) error) error{
+func (store *InMemoryLaptopStore) Update(laptop *pb.Laptop, expectedRevision uint64) error{
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	old := store.data[laptop.Id]
+	err := checkRevision(old, expectedRevision)
+	if err != nil{
+		return err
+	}
+
+	return store.replace(laptop, old)
+}
+
+func (store *InMemoryLaptopStore) Upsert(laptop *pb.Laptop) (bool, error){
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	old := store.data[laptop.Id]
+	if old == nil{
+		return true, store.save(laptop)
+	}
+
+	// replacing a deleted laptop restores it, which counts as creating it
+	return old.DeletedAt != nil, store.replace(laptop, old)
+}
+
+// replace stores a copy of the laptop in place of old, the stored version,
+// restoring old if it is deleted. The mutex must be locked.
+func (store *InMemoryLaptopStore) replace(laptop *pb.Laptop, old *pb.Laptop) error{
+	other := writtenCopy(laptop)
+	other.Revision = store.revision + 1
+
+	err := store.persist(other)
+	if err != nil{
+		return err
+	}
+
+	store.revision = other.Revision
+	laptop.Revision = other.Revision
+
+	store.data[other.Id] = other
+	store.textIndex.add(other)
+	store.indexes.remove(old)
+	store.indexes.add(other)
+	changeType := pb.LaptopChange_UPDATED
+	if old.DeletedAt != nil{
+		changeType = pb.LaptopChange_UNDELETED
+	}
+	store.changes.append(changeType, old, other)
+	return nil
+}
+
+func (store *InMemoryLaptopStore) Patch(ID string, patch *pb.Laptop, paths []string, expectedRevision uint64) (*pb.Laptop, error){
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	laptop := store.data[ID]
+	err := checkRevision(laptop, expectedRevision)
+	if err != nil{
+		return nil, err
+	}
+
+	// nested messages are modified in place, so work on a full clone
+	other := proto.Clone(laptop).(*pb.Laptop)
+
+	err = applyFieldMask(other, patch, paths)
+	if err != nil{
+		return nil, err
+	}
+
+	other.Revision = store.revision + 1
+	err = store.persist(other)
+	if err != nil{
+		return nil, err
+	}
+	store.revision = other.Revision
+
+	store.data[ID] = other
+	store.textIndex.add(other)
+	store.indexes.remove(laptop)
+	store.indexes.add(other)
+	store.changes.append(pb.LaptopChange_UPDATED, laptop, other)
+	return other, nil
+}
+
+func (store *InMemoryLaptopStore) Delete(ID string, expectedRevision uint64) error{
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	laptop := store.data[ID]
+	err := checkRevision(laptop, expectedRevision)
+	if err != nil{
+		return err
+	}
+
+	other := proto.Clone(laptop).(*pb.Laptop)
+	other.DeletedAt = timestamppb.Now()
+
+	other.Revision = store.revision + 1
+	err = store.persist(other)
+	if err != nil{
+		return err
+	}
+	store.revision = other.Revision
+
+	// deleted laptops stay indexed so that searches can show them
+	store.data[ID] = other
+	store.indexes.remove(laptop)
+	store.indexes.add(other)
+	store.changes.append(pb.LaptopChange_DELETED, laptop, nil)
+	return nil
+}
+
+func (store *InMemoryLaptopStore) Undelete(ID string, expectedRevision uint64) (*pb.Laptop, error){
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	laptop := store.data[ID]
+	if laptop == nil{
+		return nil, ErrNotFound
+	}
+	if laptop.DeletedAt == nil{
+		return nil, ErrNotDeleted
+	}
+	if expectedRevision != 0 && laptop.Revision != expectedRevision{
+		return nil, fmt.Errorf("%w: expected %d, got %d", ErrRevisionMismatch, expectedRevision, laptop.Revision)
+	}
+
+	other := proto.Clone(laptop).(*pb.Laptop)
+	other.DeletedAt = nil
+
+	other.Revision = store.revision + 1
+	err := store.persist(other)
+	if err != nil{
+		return nil, err
+	}
+	store.revision = other.Revision
+
+	store.data[ID] = other
+	store.indexes.remove(laptop)
+	store.indexes.add(other)
+	store.changes.append(pb.LaptopChange_UNDELETED, laptop, other)
+	return other, nil
+}
+
+func (store *InMemoryLaptopStore) Purge(deletedBefore time.Time) ([]string, error){
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	purged := []string{}
+	for _, id := range store.order{
+		laptop := store.data[id]
+		if laptop.DeletedAt != nil && laptop.DeletedAt.AsTime().Before(deletedBefore){
+			purged = append(purged, id)
+		}
+	}
+	if len(purged) == 0{
+		return purged, nil
+	}
+
+	err := store.persistRemoved(purged...)
+	if err != nil{
+		return nil, err
+	}
+
+	order := store.order[:0]
+	for _, id := range store.order{
+		laptop := store.data[id]
+		if laptop.DeletedAt == nil || !laptop.DeletedAt.AsTime().Before(deletedBefore){
+			order = append(order, id)
+			continue
+		}
+
+		delete(store.data, id)
+		delete(store.sequences, id)
+		store.textIndex.remove(id)
+		store.indexes.remove(laptop)
+		store.changes.append(pb.LaptopChange_PURGED, laptop, nil)
+	}
+
+	store.order = order
+	return purged, nil
+}
+
+func (store *InMemoryLaptopStore) Search(query *SearchQuery, found func(*pb.Laptop) error) (string, error){
+	return store.search(query, found, true)
+}
+
+// search looks up the laptops of the query, using the secondary indexes to
+// narrow down the laptops to scan if useIndexes is set. The results are sent
+// once the mutex is unlocked, so that found does not hold up writes.
+func (store *InMemoryLaptopStore) search(query *SearchQuery, found func(*pb.Laptop) error, useIndexes bool) (string, error){
+	orderBy := query.ordering()
+	cursor, err := decodePageToken(query.PageToken, orderBy)
+	if err != nil{
+		return "", err
+	}
+
+	match, err := query.matcher()
+	if err != nil{
+		return "", err
+	}
+
+	results := store.searchResults(query, orderBy, cursor, match, useIndexes)
+	results = sortSearchResults(results, orderBy, cursor)
+	return sendSearchResults(results, orderBy, query.PageSize, found)
+}
+
+// searchResults returns the stored laptops matching the query
+func (store *InMemoryLaptopStore) searchResults(query *SearchQuery, orderBy *pb.OrderBy, cursor *searchResult, match laptopPredicate, useIndexes bool) []*searchResult{
 	store.mutex.RLock()
 	defer store.mutex.RUnlock()
 
-	for _, laptop := range store.data{
-		if (isQualified(filter, laptop)){
-			other, err := deepCopy(laptop)
-			if err != nil{
-				return err
-			}
-
-			err = found(other)
-			if err != nil {
-				return err
-			}
+	var relevance map[string]float64
+	if query.Text != ""{
+		relevance = store.textIndex.search(query.Text)
+	}
+
+	// laptops created before the cursor cannot be on the next page of the default order
+	start := 0
+	if cursor != nil && orderBy.GetField() == pb.OrderBy_DEFAULT{
+		start = store.position(cursor.sequence + 1)
+	}
+
+	ids := store.order[start:]
+	if useIndexes && query.appliesFilter(){
+		candidates := store.indexes.candidates(query.Filter)
+		if len(candidates) < len(ids){
+			ids = candidates
 		}
 	}
+
+	results := []*searchResult{}
+	for _, id := range ids{
+		if relevance != nil && relevance[id] == 0{
+			continue
+		}
+
+		laptop := store.data[id]
+		if (laptop.DeletedAt != nil && !query.ShowDeleted) || !match(laptop){
+			continue
+		}
+
+		results = append(results, &searchResult{
+			laptop: laptop,
+			key: sortKey(laptop, orderBy.GetField(), query.Ratings, relevance),
+			sequence: store.sequences[id],
+		})
+	}
+
+	return results
+}
+
+func (store *InMemoryLaptopStore) Facets(filter *pb.Filter, widths HistogramWidths) (*pb.SearchFacetsResponse, error){
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	counter := newFacetCounter(widths)
+	for _, id := range store.indexes.candidates(filter){
+		laptop := store.data[id]
+		if laptop.DeletedAt == nil && isQualified(filter, laptop){
+			counter.add(laptop)
+		}
+	}
+
+	return counter.response(), nil
+}
+
+func (store *InMemoryLaptopStore) Watch() LaptopWatch{
+	return &laptopWatch{changes: store.changes.watchLatest()}
+}
+
+func (store *InMemoryLaptopStore) WatchChanges(afterSequence uint64) (LaptopChangeWatch, error){
+	return store.changes.watch(afterSequence)
+}
+
+// get returns the stored laptop, deleted or not, or nil if there is none
+func (store *InMemoryLaptopStore) get(ID string) (*pb.Laptop, error){
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	return store.data[ID], nil
+}
+
+// load adds laptops that were stored before, keeping their revisions and
+// deletion times. Each laptop comes with the sequence in which it was created,
+// the sequences must be increasing. No changes are recorded.
+func (store *InMemoryLaptopStore) load(laptops []*pb.Laptop, sequences []uint64) error{
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	for i, laptop := range laptops{
+		if store.data[laptop.Id] != nil{
+			return fmt.Errorf("laptop %s: %w", laptop.Id, ErrAlreadyExists)
+		}
+		if sequences[i] <= store.sequence{
+			return fmt.Errorf("laptop %s: sequence %d is out of order", laptop.Id, sequences[i])
+		}
+
+		store.restore(laptop, sequences[i])
+	}
+
+	return nil
+}
+
+// restore puts a laptop that was stored before, keeping its revision and
+// deletion time. A laptop that is not in the store yet gets the sequence, or
+// the next one if it is 0. No change is recorded. The mutex must be locked.
+func (store *InMemoryLaptopStore) restore(laptop *pb.Laptop, sequence uint64){
+	old := store.data[laptop.Id]
+	if old == nil{
+		if sequence == 0{
+			sequence = store.sequence + 1
+		}
+		store.sequence = sequence
+		store.sequences[laptop.Id] = sequence
+		store.order = append(store.order, laptop.Id)
+	}else{
+		store.indexes.remove(old)
+	}
+
+	store.data[laptop.Id] = laptop
+	if laptop.Revision > store.revision{
+		store.revision = laptop.Revision
+	}
+
+	store.textIndex.add(laptop)
+	store.indexes.add(laptop)
+}
+
+// unstore removes a laptop without recording a change. The mutex must be locked.
+func (store *InMemoryLaptopStore) unstore(ID string){
+	laptop := store.data[ID]
+	if laptop == nil{
+		return
+	}
+
+	i := store.position(store.sequences[ID])
+	store.order = append(store.order[:i], store.order[i+1:]...)
+
+	delete(store.data, ID)
+	delete(store.sequences, ID)
+	store.textIndex.remove(ID)
+	store.indexes.remove(laptop)
+}
+
+// all returns the stored laptops, deleted or not, in the order they were created
+func (store *InMemoryLaptopStore) all() ([]*pb.Laptop, error){
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	laptops := make([]*pb.Laptop, len(store.order))
+	for i, id := range store.order{
+		laptops[i] = store.data[id]
+	}
+
+	return laptops, nil
+}
+
+// position returns the index in store.order of the first laptop created with a sequence not less than the given one
+func (store *InMemoryLaptopStore) position(sequence uint64) int {
+	return sort.Search(len(store.order), func(i int) bool {
+		return store.sequences[store.order[i]] >= sequence
+	})
+}
+
+// checkRevision makes sure the stored laptop exists, is not deleted and, if expectedRevision is set, has that revision
+func checkRevision(laptop *pb.Laptop, expectedRevision uint64) error {
+	if laptop == nil || laptop.DeletedAt != nil {
+		return ErrNotFound
+	}
+
+	if expectedRevision != 0 && laptop.GetRevision() != expectedRevision {
+		return fmt.Errorf("%w: expected %d, got %d", ErrRevisionMismatch, expectedRevision, laptop.GetRevision())
+	}
+
 	return nil
 }
 
@@ -83,6 +595,10 @@ func isQualified(filter *pb.Filter, laptop *pb.Laptop) bool {
 		return false
 	}
 
+	if laptop.GetPriceUsd() < filter.GetMinPriceUsd() {
+		return false
+	}
+
 	if laptop.GetCpu().GetNumberCores() < filter.GetMinCpuCores() {
 		return false
 	}
@@ -95,9 +611,139 @@ func isQualified(filter *pb.Filter, laptop *pb.Laptop) bool {
 		return false
 	}
 
+	if !containsFold(filter.GetBrands(), laptop.GetBrand()) {
+		return false
+	}
+
+	if !containsFold(filter.GetNames(), laptop.GetName()) {
+		return false
+	}
+
+	if laptop.GetReleaseYear() < filter.GetMinReleaseYear() {
+		return false
+	}
+
+	if filter.GetMaxReleaseYear() > 0 && laptop.GetReleaseYear() > filter.GetMaxReleaseYear() {
+		return false
+	}
+
+	if !hasQualifiedGPU(filter, laptop) {
+		return false
+	}
+
+	if storageSize(laptop, pb.Storage_SSD) < toBit(filter.GetMinSsd()) {
+		return false
+	}
+
+	if storageSize(laptop, pb.Storage_HDD) < toBit(filter.GetMinHdd()) {
+		return false
+	}
+
+	if !isScreenQualified(filter, laptop.GetScreen()) {
+		return false
+	}
+
+	if filter.GetKeyboardLayout() != pb.Keyboard_UNKNOWN && laptop.GetKeyboard().GetLayout() != filter.GetKeyboardLayout() {
+		return false
+	}
+
+	if filter.GetRequireBacklit() && !laptop.GetKeyboard().GetBacklit() {
+		return false
+	}
+
+	if !isWeightQualified(filter, laptop) {
+		return false
+	}
+
+	return true
+}
+
+// containsFold reports whether value is in values ignoring case, an empty list contains everything
+func containsFold(values []string, value string) bool {
+	if len(values) == 0 {
+		return true
+	}
+
+	for _, other := range values {
+		if strings.EqualFold(other, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasQualifiedGPU(filter *pb.Filter, laptop *pb.Laptop) bool {
+	if filter.GetGpuBrand() == "" && toBit(filter.GetMinGpuMemory()) == 0 {
+		return true
+	}
+
+	for _, gpu := range laptop.GetGpus() {
+		if filter.GetGpuBrand() != "" && !strings.EqualFold(gpu.GetBrand(), filter.GetGpuBrand()) {
+			continue
+		}
+
+		if toBit(gpu.GetMemory()) >= toBit(filter.GetMinGpuMemory()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// storageSize returns the total capacity in bits of all storages with the given driver
+func storageSize(laptop *pb.Laptop, driver pb.Storage_Driver) uint64 {
+	size := uint64(0)
+	for _, storage := range laptop.GetStorages() {
+		if storage.GetDriver() == driver {
+			size += toBit(storage.GetMemory())
+		}
+	}
+
+	return size
+}
+
+func isScreenQualified(filter *pb.Filter, screen *pb.Screen) bool {
+	if screen.GetSizeInch() < filter.GetMinScreenSizeInch() {
+		return false
+	}
+
+	if filter.GetMaxScreenSizeInch() > 0 && screen.GetSizeInch() > filter.GetMaxScreenSizeInch() {
+		return false
+	}
+
+	resolution := filter.GetMinScreenResolution()
+	if screen.GetResolution().GetWidth() < resolution.GetWidth() || screen.GetResolution().GetHeight() < resolution.GetHeight() {
+		return false
+	}
+
+	if filter.GetScreenPannel() != pb.Screen_UNKNOWN && screen.GetPannel() != filter.GetScreenPannel() {
+		return false
+	}
+
+	if filter.GetRequireMultitouch() && !screen.GetMultitouch() {
+		return false
+	}
+
 	return true
 }
 
+func isWeightQualified(filter *pb.Filter, laptop *pb.Laptop) bool {
+	maxWeightKg := 0.0
+	switch weight := filter.GetMaxWeight().(type) {
+	case *pb.Filter_MaxWeightKg:
+		maxWeightKg = weight.MaxWeightKg
+	case *pb.Filter_MaxWeightLb:
+		maxWeightKg = weight.MaxWeightLb * poundToKg
+	}
+
+	if maxWeightKg <= 0 {
+		return true
+	}
+
+	return weightKg(laptop) <= maxWeightKg
+}
+
 func toBit(memory *pb.Memory) uint64 {
 	value := memory.GetValue()
 
@@ -119,13 +765,16 @@ func toBit(memory *pb.Memory) uint64 {
 	}
 }
 
-func deepCopy(laptop *pb.Laptop) (*pb.Laptop, error) {
-	other := &pb.Laptop{}
-
-	err := copier.Copy(other, laptop)
-	if err != nil {
-		return nil, fmt.Errorf("cannot copy laptop data: %w", err)
-	}
+// deepCopy returns a copy of the laptop sharing no memory with it, including
+// the oneof fields and the repeated messages
+func deepCopy(laptop *pb.Laptop) *pb.Laptop {
+	return proto.Clone(laptop).(*pb.Laptop)
+}
 
-	return other, nil
+// writtenCopy returns the copy of a laptop given to Save, Update or Upsert,
+// which is never deleted: only Delete and Undelete change DeletedAt
+func writtenCopy(laptop *pb.Laptop) *pb.Laptop {
+	other := deepCopy(laptop)
+	other.DeletedAt = nil
+	return other
 }
\ No newline at end of file
@@ -0,0 +1,827 @@
+package service_test
+
+import (
+	"context"
+	"gRPC/pb"
+	"gRPC/sample"
+	"gRPC/service"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemoryLaptopStoreSearchPagination(t *testing.T){
+	t.Parallel()
+
+	store := service.NewInMemoryLaptopStore()
+	filter := &pb.Filter{
+		MaxPriceUsd: 5000,
+	}
+
+	expectedIDs := []string{}
+	for i := 0; i < 10; i++{
+		laptop := sample.NewLaptop()
+		err := store.Save(laptop)
+		require.NoError(t, err)
+		expectedIDs = append(expectedIDs, laptop.Id)
+	}
+
+	err := store.Delete(expectedIDs[4], 0)
+	require.NoError(t, err)
+	expectedIDs = append(expectedIDs[:4], expectedIDs[5:]...)
+
+	foundIDs := []string{}
+	query := &service.SearchQuery{
+		Filter: filter,
+		PageSize: 3,
+	}
+
+	for {
+		page := []string{}
+		nextPageToken, err := store.Search(query, func(laptop *pb.Laptop) error {
+			page = append(page, laptop.Id)
+			return nil
+		})
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(page), 3)
+		foundIDs = append(foundIDs, page...)
+
+		// laptops created while paging show up on a later page
+		laptop := sample.NewLaptop()
+		if len(foundIDs) < 12{
+			err = store.Save(laptop)
+			require.NoError(t, err)
+			expectedIDs = append(expectedIDs, laptop.Id)
+		}
+
+		if nextPageToken == ""{
+			break
+		}
+		query.PageToken = nextPageToken
+	}
+
+	require.Equal(t, expectedIDs, foundIDs)
+}
+
+func TestInMemoryLaptopStoreSearchInvalidPageToken(t *testing.T){
+	t.Parallel()
+
+	store := service.NewInMemoryLaptopStore()
+	query := &service.SearchQuery{
+		Filter: &pb.Filter{MaxPriceUsd: 5000},
+		PageToken: "not a token",
+	}
+
+	_, err := store.Search(query, func(laptop *pb.Laptop) error {
+		return nil
+	})
+	require.ErrorIs(t, err, service.ErrInvalidPageToken)
+}
+
+func TestInMemoryLaptopStoreSearchUnencodablePageToken(t *testing.T){
+	t.Parallel()
+
+	store := service.NewInMemoryLaptopStore()
+	for i := 0; i < 2; i++{
+		laptop := sample.NewLaptop()
+		laptop.PriceUsd = math.NaN()
+		err := store.Save(laptop)
+		require.NoError(t, err)
+	}
+
+	query := &service.SearchQuery{
+		Expression: textPredicate("brand", pb.Predicate_NOT_EQUAL, ""),
+		OrderBy: &pb.OrderBy{Field: pb.OrderBy_PRICE_USD},
+		PageSize: 1,
+	}
+	_, err := store.Search(query, func(laptop *pb.Laptop) error {
+		return nil
+	})
+	require.Error(t, err)
+	require.NotErrorIs(t, err, service.ErrInvalidPageToken)
+}
+
+func TestInMemoryLaptopStoreSearchOrderBy(t *testing.T){
+	t.Parallel()
+
+	store := service.NewInMemoryLaptopStore()
+	ratings := service.NewInMemoryRatingStore()
+
+	laptops := make([]*pb.Laptop, 4)
+	for i := range laptops{
+		laptops[i] = sample.NewLaptop()
+	}
+
+	laptops[0].PriceUsd = 3000
+	laptops[0].ReleaseYear = 2018
+	laptops[0].Weight = &pb.Laptop_WeightKg{WeightKg: 2}
+	laptops[0].Ram = &pb.Memory{Value: 16, Unit: pb.Memory_GIGABYTE}
+
+	laptops[1].PriceUsd = 1500
+	laptops[1].ReleaseYear = 2019
+	laptops[1].Weight = &pb.Laptop_WeightLb{WeightLb: 3} // ~1.36 kg
+	laptops[1].Ram = &pb.Memory{Value: 8192, Unit: pb.Memory_MEGABYTE}
+
+	laptops[2].PriceUsd = 2000
+	laptops[2].ReleaseYear = 2016
+	laptops[2].Weight = &pb.Laptop_WeightKg{WeightKg: 1.5}
+	laptops[2].Ram = &pb.Memory{Value: 32, Unit: pb.Memory_GIGABYTE}
+
+	laptops[3].PriceUsd = 2500
+	laptops[3].ReleaseYear = 2019
+	laptops[3].Weight = &pb.Laptop_WeightLb{WeightLb: 6} // ~2.72 kg
+	laptops[3].Ram = &pb.Memory{Value: 4, Unit: pb.Memory_GIGABYTE}
+
+	for _, laptop := range laptops{
+		err := store.Save(laptop)
+		require.NoError(t, err)
+	}
+
+	for laptopID, score := range map[string]float64{laptops[0].Id: 5, laptops[2].Id: 9, laptops[3].Id: 7}{
+		_, err := ratings.Add(laptopID, score)
+		require.NoError(t, err)
+	}
+
+	testCases := []struct{
+		name string
+		orderBy *pb.OrderBy
+		expected []int
+	}{
+		{
+			name: "default",
+			orderBy: nil,
+			expected: []int{0, 1, 2, 3},
+		},
+		{
+			name: "price_asc",
+			orderBy: &pb.OrderBy{Field: pb.OrderBy_PRICE_USD},
+			expected: []int{1, 2, 3, 0},
+		},
+		{
+			name: "release_year_desc",
+			orderBy: &pb.OrderBy{Field: pb.OrderBy_RELEASE_YEAR, Descending: true},
+			expected: []int{1, 3, 0, 2},
+		},
+		{
+			name: "ram_asc",
+			orderBy: &pb.OrderBy{Field: pb.OrderBy_RAM},
+			expected: []int{3, 1, 0, 2},
+		},
+		{
+			name: "weight_asc",
+			orderBy: &pb.OrderBy{Field: pb.OrderBy_WEIGHT},
+			expected: []int{1, 2, 0, 3},
+		},
+		{
+			name: "rating_desc",
+			orderBy: &pb.OrderBy{Field: pb.OrderBy_AVERAGE_RATING, Descending: true},
+			expected: []int{2, 3, 0, 1},
+		},
+	}
+
+	for i := range testCases{
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			query := &service.SearchQuery{
+				Filter: &pb.Filter{MaxPriceUsd: 5000},
+				OrderBy: tc.orderBy,
+				PageSize: 3,
+				Ratings: ratings,
+			}
+
+			foundIDs := []string{}
+			for {
+				nextPageToken, err := store.Search(query, func(laptop *pb.Laptop) error {
+					foundIDs = append(foundIDs, laptop.Id)
+					return nil
+				})
+				require.NoError(t, err)
+
+				if nextPageToken == ""{
+					break
+				}
+				query.PageToken = nextPageToken
+			}
+
+			expectedIDs := []string{}
+			for _, index := range tc.expected{
+				expectedIDs = append(expectedIDs, laptops[index].Id)
+			}
+			require.Equal(t, expectedIDs, foundIDs)
+		})
+	}
+}
+
+func TestInMemoryLaptopStoreSearchPageTokenOrder(t *testing.T){
+	t.Parallel()
+
+	store := service.NewInMemoryLaptopStore()
+	for i := 0; i < 3; i++{
+		err := store.Save(sample.NewLaptop())
+		require.NoError(t, err)
+	}
+
+	query := &service.SearchQuery{
+		Expression: textPredicate("brand", pb.Predicate_NOT_EQUAL, ""),
+		OrderBy: &pb.OrderBy{Field: pb.OrderBy_PRICE_USD},
+		PageSize: 1,
+	}
+
+	nextPageToken, err := store.Search(query, func(laptop *pb.Laptop) error {
+		return nil
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, nextPageToken)
+
+	// the token cannot be used with another order
+	query.OrderBy.Descending = true
+	query.PageToken = nextPageToken
+	_, err = store.Search(query, func(laptop *pb.Laptop) error {
+		return nil
+	})
+	require.ErrorIs(t, err, service.ErrInvalidPageToken)
+}
+
+func newFilterTestLaptop() *pb.Laptop {
+	laptop := sample.NewLaptop()
+	laptop.Brand = "Dell"
+	laptop.Name = "XPS"
+	laptop.PriceUsd = 2000
+	laptop.ReleaseYear = 2018
+	laptop.Cpu.NumberCores = 4
+	laptop.Cpu.MinGhz = 2.5
+	laptop.Ram = &pb.Memory{Value: 16, Unit: pb.Memory_GIGABYTE}
+	laptop.Gpus = []*pb.GPU{
+		{Brand: "AMD", Name: "RX 580", Memory: &pb.Memory{Value: 8, Unit: pb.Memory_GIGABYTE}},
+		{Brand: "Nvidia", Name: "GTX 1070", Memory: &pb.Memory{Value: 4, Unit: pb.Memory_GIGABYTE}},
+	}
+	laptop.Storages = []*pb.Storage{
+		{Driver: pb.Storage_SSD, Memory: &pb.Memory{Value: 256, Unit: pb.Memory_GIGABYTE}},
+		{Driver: pb.Storage_SSD, Memory: &pb.Memory{Value: 512, Unit: pb.Memory_GIGABYTE}},
+		{Driver: pb.Storage_HDD, Memory: &pb.Memory{Value: 1, Unit: pb.Memory_TERABYTE}},
+	}
+	laptop.Screen = &pb.Screen{
+		SizeInch: 15.6,
+		Resolution: &pb.Screen_Resolution{Width: 1920, Height: 1080},
+		Pannel: pb.Screen_IPS,
+		Multitouch: false,
+	}
+	laptop.Keyboard = &pb.Keyboard{Layout: pb.Keyboard_US, Backlit: true}
+	laptop.Weight = &pb.Laptop_WeightLb{WeightLb: 4} // ~1.81 kg
+	return laptop
+}
+
+func TestInMemoryLaptopStoreSearchFilter(t *testing.T){
+	t.Parallel()
+
+	store := service.NewInMemoryLaptopStore()
+	laptop := newFilterTestLaptop()
+	err := store.Save(laptop)
+	require.NoError(t, err)
+
+	testCases := []struct{
+		name string
+		filter *pb.Filter
+		found bool
+	}{
+		{name: "max_price", filter: &pb.Filter{}, found: false},
+		{name: "min_price_ok", filter: &pb.Filter{MaxPriceUsd: 5000, MinPriceUsd: 2000}, found: true},
+		{name: "min_price_too_high", filter: &pb.Filter{MaxPriceUsd: 5000, MinPriceUsd: 2001}, found: false},
+		{name: "min_ram_other_unit", filter: &pb.Filter{MaxPriceUsd: 5000, MinRam: &pb.Memory{Value: 16384, Unit: pb.Memory_MEGABYTE}}, found: true},
+		{name: "min_ram_too_high", filter: &pb.Filter{MaxPriceUsd: 5000, MinRam: &pb.Memory{Value: 17, Unit: pb.Memory_GIGABYTE}}, found: false},
+		{name: "brands_match", filter: &pb.Filter{MaxPriceUsd: 5000, Brands: []string{"Lenovo", "dell"}}, found: true},
+		{name: "brands_no_match", filter: &pb.Filter{MaxPriceUsd: 5000, Brands: []string{"Apple", "Lenovo"}}, found: false},
+		{name: "names_match", filter: &pb.Filter{MaxPriceUsd: 5000, Names: []string{"xps"}}, found: true},
+		{name: "names_no_match", filter: &pb.Filter{MaxPriceUsd: 5000, Names: []string{"Vostro"}}, found: false},
+		{name: "release_year_range", filter: &pb.Filter{MaxPriceUsd: 5000, MinReleaseYear: 2017, MaxReleaseYear: 2018}, found: true},
+		{name: "release_year_too_old", filter: &pb.Filter{MaxPriceUsd: 5000, MinReleaseYear: 2019}, found: false},
+		{name: "release_year_too_new", filter: &pb.Filter{MaxPriceUsd: 5000, MaxReleaseYear: 2017}, found: false},
+		{name: "gpu_brand", filter: &pb.Filter{MaxPriceUsd: 5000, GpuBrand: "nvidia"}, found: true},
+		{name: "gpu_brand_missing", filter: &pb.Filter{MaxPriceUsd: 5000, GpuBrand: "Intel"}, found: false},
+		{name: "gpu_memory", filter: &pb.Filter{MaxPriceUsd: 5000, MinGpuMemory: &pb.Memory{Value: 8, Unit: pb.Memory_GIGABYTE}}, found: true},
+		{name: "gpu_brand_and_memory_same_gpu", filter: &pb.Filter{MaxPriceUsd: 5000, GpuBrand: "Nvidia", MinGpuMemory: &pb.Memory{Value: 8, Unit: pb.Memory_GIGABYTE}}, found: false},
+		{name: "ssd_total", filter: &pb.Filter{MaxPriceUsd: 5000, MinSsd: &pb.Memory{Value: 768, Unit: pb.Memory_GIGABYTE}}, found: true},
+		{name: "ssd_total_too_high", filter: &pb.Filter{MaxPriceUsd: 5000, MinSsd: &pb.Memory{Value: 1, Unit: pb.Memory_TERABYTE}}, found: false},
+		{name: "hdd_total", filter: &pb.Filter{MaxPriceUsd: 5000, MinHdd: &pb.Memory{Value: 1024, Unit: pb.Memory_GIGABYTE}}, found: true},
+		{name: "hdd_total_too_high", filter: &pb.Filter{MaxPriceUsd: 5000, MinHdd: &pb.Memory{Value: 2, Unit: pb.Memory_TERABYTE}}, found: false},
+		{name: "screen_size_range", filter: &pb.Filter{MaxPriceUsd: 5000, MinScreenSizeInch: 15, MaxScreenSizeInch: 16}, found: true},
+		{name: "screen_too_small", filter: &pb.Filter{MaxPriceUsd: 5000, MinScreenSizeInch: 16}, found: false},
+		{name: "screen_too_big", filter: &pb.Filter{MaxPriceUsd: 5000, MaxScreenSizeInch: 14}, found: false},
+		{name: "screen_resolution", filter: &pb.Filter{MaxPriceUsd: 5000, MinScreenResolution: &pb.Screen_Resolution{Width: 1920, Height: 1080}}, found: true},
+		{name: "screen_resolution_too_high", filter: &pb.Filter{MaxPriceUsd: 5000, MinScreenResolution: &pb.Screen_Resolution{Width: 1920, Height: 1200}}, found: false},
+		{name: "screen_pannel", filter: &pb.Filter{MaxPriceUsd: 5000, ScreenPannel: pb.Screen_IPS}, found: true},
+		{name: "screen_pannel_no_match", filter: &pb.Filter{MaxPriceUsd: 5000, ScreenPannel: pb.Screen_OLED}, found: false},
+		{name: "multitouch", filter: &pb.Filter{MaxPriceUsd: 5000, RequireMultitouch: true}, found: false},
+		{name: "keyboard_layout", filter: &pb.Filter{MaxPriceUsd: 5000, KeyboardLayout: pb.Keyboard_US}, found: true},
+		{name: "keyboard_layout_no_match", filter: &pb.Filter{MaxPriceUsd: 5000, KeyboardLayout: pb.Keyboard_VN}, found: false},
+		{name: "backlit", filter: &pb.Filter{MaxPriceUsd: 5000, RequireBacklit: true}, found: true},
+		{name: "max_weight_kg", filter: &pb.Filter{MaxPriceUsd: 5000, MaxWeight: &pb.Filter_MaxWeightKg{MaxWeightKg: 1.9}}, found: true},
+		{name: "max_weight_kg_too_low", filter: &pb.Filter{MaxPriceUsd: 5000, MaxWeight: &pb.Filter_MaxWeightKg{MaxWeightKg: 1.8}}, found: false},
+		{name: "max_weight_lb", filter: &pb.Filter{MaxPriceUsd: 5000, MaxWeight: &pb.Filter_MaxWeightLb{MaxWeightLb: 4}}, found: true},
+		{name: "max_weight_lb_too_low", filter: &pb.Filter{MaxPriceUsd: 5000, MaxWeight: &pb.Filter_MaxWeightLb{MaxWeightLb: 3.9}}, found: false},
+	}
+
+	for i := range testCases{
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			found := false
+			_, err := store.Search(&service.SearchQuery{Filter: tc.filter}, func(other *pb.Laptop) error {
+				require.Equal(t, laptop.Id, other.Id)
+				found = true
+				return nil
+			})
+			require.NoError(t, err)
+			require.Equal(t, tc.found, found)
+		})
+	}
+}
+
+func textPredicate(field string, operator pb.Predicate_Operator, text string) *pb.FilterExpression {
+	return &pb.FilterExpression{
+		Node: &pb.FilterExpression_Predicate{
+			Predicate: &pb.Predicate{Field: field, Operator: operator, Value: &pb.Predicate_Text{Text: text}},
+		},
+	}
+}
+
+func numberPredicate(field string, operator pb.Predicate_Operator, number float64) *pb.FilterExpression {
+	return &pb.FilterExpression{
+		Node: &pb.FilterExpression_Predicate{
+			Predicate: &pb.Predicate{Field: field, Operator: operator, Value: &pb.Predicate_Number{Number: number}},
+		},
+	}
+}
+
+func and(expressions ...*pb.FilterExpression) *pb.FilterExpression {
+	return &pb.FilterExpression{
+		Node: &pb.FilterExpression_And{And: &pb.FilterExpression_List{Expressions: expressions}},
+	}
+}
+
+func or(expressions ...*pb.FilterExpression) *pb.FilterExpression {
+	return &pb.FilterExpression{
+		Node: &pb.FilterExpression_Or{Or: &pb.FilterExpression_List{Expressions: expressions}},
+	}
+}
+
+func not(expression *pb.FilterExpression) *pb.FilterExpression {
+	return &pb.FilterExpression{
+		Node: &pb.FilterExpression_Not{Not: expression},
+	}
+}
+
+func searchIDs(t *testing.T, store service.LaptopStore, query *service.SearchQuery) []string {
+	ids := []string{}
+	_, err := store.Search(query, func(laptop *pb.Laptop) error {
+		ids = append(ids, laptop.Id)
+		return nil
+	})
+	require.NoError(t, err)
+	return ids
+}
+
+func TestInMemoryLaptopStoreSearchExpression(t *testing.T){
+	t.Parallel()
+
+	store := service.NewInMemoryLaptopStore()
+	ssd := &pb.Storage{Driver: pb.Storage_SSD, Memory: &pb.Memory{Value: 512, Unit: pb.Memory_GIGABYTE}}
+	hdd := &pb.Storage{Driver: pb.Storage_HDD, Memory: &pb.Memory{Value: 1, Unit: pb.Memory_TERABYTE}}
+
+	dellSSD := sample.NewLaptop()
+	dellSSD.Brand = "Dell"
+	dellSSD.Storages = []*pb.Storage{ssd}
+	dellSSD.Ram = &pb.Memory{Value: 32, Unit: pb.Memory_GIGABYTE}
+
+	lenovoHDD := sample.NewLaptop()
+	lenovoHDD.Brand = "Lenovo"
+	lenovoHDD.Storages = []*pb.Storage{hdd}
+
+	appleSSD := sample.NewLaptop()
+	appleSSD.Brand = "Apple"
+	appleSSD.Storages = []*pb.Storage{ssd}
+
+	dellBoth := sample.NewLaptop()
+	dellBoth.Brand = "Dell"
+	dellBoth.Storages = []*pb.Storage{hdd, ssd}
+	dellBoth.Ram = &pb.Memory{Value: 8, Unit: pb.Memory_GIGABYTE}
+
+	for _, laptop := range []*pb.Laptop{dellSSD, lenovoHDD, appleSSD, dellBoth}{
+		err := store.Save(laptop)
+		require.NoError(t, err)
+	}
+
+	hddOnly := and(
+		textPredicate("storage.driver", pb.Predicate_EQUAL, "HDD"),
+		textPredicate("storage.driver", pb.Predicate_NOT_EQUAL, "SSD"),
+	)
+	expression := and(
+		or(
+			textPredicate("brand", pb.Predicate_EQUAL, "dell"),
+			textPredicate("brand", pb.Predicate_EQUAL, "Lenovo"),
+		),
+		not(hddOnly),
+	)
+
+	ids := searchIDs(t, store, &service.SearchQuery{Expression: expression})
+	require.Equal(t, []string{dellSSD.Id, dellBoth.Id}, ids)
+
+	// the filter still applies alongside the expression
+	ids = searchIDs(t, store, &service.SearchQuery{Filter: &pb.Filter{MaxPriceUsd: 5000, MinRam: dellBoth.Ram}, Expression: expression})
+	require.NotContains(t, ids, lenovoHDD.Id)
+	require.NotContains(t, ids, appleSSD.Id)
+	require.Contains(t, ids, dellBoth.Id)
+
+	ramBelow16GB := &pb.FilterExpression{
+		Node: &pb.FilterExpression_Predicate{
+			Predicate: &pb.Predicate{
+				Field: "ram",
+				Operator: pb.Predicate_LESS,
+				Value: &pb.Predicate_Memory{Memory: &pb.Memory{Value: 16384, Unit: pb.Memory_MEGABYTE}},
+			},
+		},
+	}
+	ids = searchIDs(t, store, &service.SearchQuery{Expression: and(ramBelow16GB, textPredicate("brand", pb.Predicate_EQUAL, "Dell"))})
+	require.Equal(t, []string{dellBoth.Id}, ids)
+}
+
+func TestInMemoryLaptopStoreSearchInvalidExpression(t *testing.T){
+	t.Parallel()
+
+	store := service.NewInMemoryLaptopStore()
+	err := store.Save(sample.NewLaptop())
+	require.NoError(t, err)
+
+	deep := textPredicate("brand", pb.Predicate_EQUAL, "Dell")
+	for i := 0; i < 40; i++{
+		deep = not(deep)
+	}
+
+	testCases := []struct{
+		name string
+		expression *pb.FilterExpression
+	}{
+		{
+			name: "empty_node",
+			expression: and(&pb.FilterExpression{}),
+		},
+		{
+			name: "empty_list",
+			expression: or(),
+		},
+		{
+			name: "unknown_field",
+			expression: textPredicate("color", pb.Predicate_EQUAL, "red"),
+		},
+		{
+			name: "missing_operator",
+			expression: textPredicate("brand", pb.Predicate_UNKNOWN, "Dell"),
+		},
+		{
+			name: "unknown_operator",
+			expression: textPredicate("brand", pb.Predicate_Operator(42), "Dell"),
+		},
+		{
+			name: "ordered_text",
+			expression: textPredicate("brand", pb.Predicate_LESS, "Dell"),
+		},
+		{
+			name: "type_mismatch",
+			expression: textPredicate("price_usd", pb.Predicate_EQUAL, "cheap"),
+		},
+		{
+			name: "nan_number",
+			expression: not(numberPredicate("price_usd", pb.Predicate_EQUAL, math.NaN())),
+		},
+		{
+			name: "infinite_number",
+			expression: numberPredicate("price_usd", pb.Predicate_LESS, math.Inf(1)),
+		},
+		{
+			name: "memory_without_unit",
+			expression: &pb.FilterExpression{
+				Node: &pb.FilterExpression_Predicate{
+					Predicate: &pb.Predicate{
+						Field: "ram",
+						Operator: pb.Predicate_GREATER,
+						Value: &pb.Predicate_Memory{Memory: &pb.Memory{Value: 16}},
+					},
+				},
+			},
+		},
+		{
+			name: "too_deep",
+			expression: deep,
+		},
+	}
+
+	for i := range testCases{
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := store.Search(&service.SearchQuery{Expression: tc.expression}, func(laptop *pb.Laptop) error {
+				return nil
+			})
+			require.ErrorIs(t, err, service.ErrInvalidExpression)
+		})
+	}
+}
+
+func TestInMemoryLaptopStoreSearchText(t *testing.T){
+	t.Parallel()
+
+	store := service.NewInMemoryLaptopStore()
+
+	newLaptop := func(brand string, name string, cpu string, gpu string) *pb.Laptop {
+		laptop := sample.NewLaptop()
+		laptop.Brand = brand
+		laptop.Name = name
+		laptop.Cpu.Name = cpu
+		laptop.Gpus = []*pb.GPU{{Brand: "Nvidia", Name: gpu}}
+		err := store.Save(laptop)
+		require.NoError(t, err)
+		return laptop
+	}
+
+	thinkpadX1 := newLaptop("Lenovo", "Thinkpad X1", "Core i7-9750H", "RTX 2070")
+	thinkpadP1 := newLaptop("Lenovo", "Thinkpad P1", "Core i5-9400F", "GTX 1070")
+	xps := newLaptop("Dell", "XPS", "Core i7-9750H", "RTX 2060")
+	macbook := newLaptop("Apple", "Macbook Pro", "Ryzen 7 PRO 2700U", "RX 580")
+
+	ids := searchIDs(t, store, &service.SearchQuery{Text: "thinkpad i7 rtx"})
+	require.Equal(t, []string{thinkpadX1.Id, xps.Id, thinkpadP1.Id}, ids)
+
+	// prefix matches rank below whole token matches
+	ids = searchIDs(t, store, &service.SearchQuery{Text: "THINK 2070"})
+	require.Equal(t, []string{thinkpadX1.Id, thinkpadP1.Id}, ids)
+
+	ids = searchIDs(t, store, &service.SearchQuery{Text: "macbook", Filter: &pb.Filter{MaxPriceUsd: 1}})
+	require.Empty(t, ids)
+
+	ids = searchIDs(t, store, &service.SearchQuery{Text: "rx"})
+	require.Equal(t, []string{macbook.Id}, ids)
+
+	// the index follows updates and deletes
+	xps.Name = "Thinkpad T14"
+	err := store.Update(xps, 0)
+	require.NoError(t, err)
+
+	err = store.Delete(thinkpadX1.Id, 0)
+	require.NoError(t, err)
+
+	ids = searchIDs(t, store, &service.SearchQuery{Text: "thinkpad"})
+	require.Equal(t, []string{thinkpadP1.Id, xps.Id}, ids)
+
+	ids = searchIDs(t, store, &service.SearchQuery{Text: "xps"})
+	require.Empty(t, ids)
+
+	ids = searchIDs(t, store, &service.SearchQuery{Text: "thinkpad", OrderBy: &pb.OrderBy{Field: pb.OrderBy_PRICE_USD}, PageSize: 1})
+	require.Len(t, ids, 1)
+}
+
+func TestInMemoryLaptopStoreWatch(t *testing.T){
+	t.Parallel()
+
+	store := service.NewInMemoryLaptopStore()
+	watch := store.Watch()
+	defer watch.Close()
+
+	laptop := sample.NewLaptop()
+	err := store.Save(laptop)
+	require.NoError(t, err)
+
+	patched, err := store.Patch(laptop.Id, &pb.Laptop{Name: "Patched"}, []string{"name"}, 0)
+	require.NoError(t, err)
+
+	err = store.Delete(laptop.Id, 0)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	other, err := watch.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, laptop.Revision, other.Revision)
+
+	// watched laptops are copies
+	other.Name = "Changed"
+	other, err = watch.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, patched.Revision, other.Revision)
+	require.Equal(t, "Patched", other.Name)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = watch.Next(canceled)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestInMemoryLaptopStoreWatchOverflow(t *testing.T){
+	t.Parallel()
+
+	store := service.NewInMemoryLaptopStoreWithChangeLog(100)
+	watch := store.Watch()
+	defer watch.Close()
+
+	var err error
+	// the watch is not read while the laptops are saved
+	for i := 0; i < 200; i++{
+		err = store.Save(sample.NewLaptop())
+		require.NoError(t, err)
+	}
+
+	for err == nil{
+		_, err = watch.Next(context.Background())
+	}
+	require.ErrorIs(t, err, service.ErrWatchOverflow)
+}
+
+func TestInMemoryLaptopStoreWatchChanges(t *testing.T){
+	t.Parallel()
+
+	store := service.NewInMemoryLaptopStoreWithChangeLog(3)
+	laptop := sample.NewLaptop()
+	err := store.Save(laptop)
+	require.NoError(t, err)
+
+	created := laptop.Revision
+	laptop.Name = "Updated"
+	err = store.Update(laptop, 0)
+	require.NoError(t, err)
+
+	err = store.Delete(laptop.Id, 0)
+	require.NoError(t, err)
+
+	watch, err := store.WatchChanges(0)
+	require.NoError(t, err)
+	defer watch.Close()
+
+	ctx := context.Background()
+	change, err := watch.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), change.Sequence)
+	require.Equal(t, pb.LaptopChange_CREATED, change.Type)
+	require.Nil(t, change.Before)
+	require.Equal(t, created, change.After.Revision)
+
+	change, err = watch.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), change.Sequence)
+	require.Equal(t, pb.LaptopChange_UPDATED, change.Type)
+	require.Equal(t, created, change.Before.Revision)
+	require.Equal(t, "Updated", change.After.Name)
+
+	change, err = watch.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), change.Sequence)
+	require.Equal(t, pb.LaptopChange_DELETED, change.Type)
+	require.Equal(t, laptop.Id, change.Before.Id)
+	require.Nil(t, change.After)
+
+	// resuming after the last change waits for the next one
+	resumed, err := store.WatchChanges(3)
+	require.NoError(t, err)
+	defer resumed.Close()
+
+	err = store.Save(sample.NewLaptop())
+	require.NoError(t, err)
+
+	change, err = resumed.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), change.Sequence)
+
+	// the first change no longer fits in the log
+	_, err = store.WatchChanges(0)
+	require.ErrorIs(t, err, service.ErrChangesTruncated)
+
+	_, err = store.WatchChanges(5)
+	require.ErrorIs(t, err, service.ErrChangesTruncated)
+}
+
+func TestInMemoryLaptopStoreSaveAll(t *testing.T){
+	t.Parallel()
+
+	store := service.NewInMemoryLaptopStore()
+	existing := sample.NewLaptop()
+	err := store.Save(existing)
+	require.NoError(t, err)
+
+	laptop := sample.NewLaptop()
+	errs := store.SaveAll([]*pb.Laptop{laptop, existing, laptop})
+	require.NoError(t, errs[0])
+	require.ErrorIs(t, errs[1], service.ErrAlreadyExists)
+	require.ErrorIs(t, errs[2], service.ErrAlreadyExists)
+
+	other, err := store.Find(laptop.Id)
+	require.NoError(t, err)
+	require.Nil(t, other)
+
+	second := sample.NewLaptop()
+	errs = store.SaveAll([]*pb.Laptop{laptop, second})
+	require.Equal(t, []error{nil, nil}, errs)
+	require.Equal(t, laptop.Revision+1, second.Revision)
+
+	other, err = store.Find(second.Id)
+	require.NoError(t, err)
+	require.Equal(t, second.Revision, other.Revision)
+}
+
+func TestInMemoryLaptopStoreSoftDelete(t *testing.T){
+	t.Parallel()
+
+	store := service.NewInMemoryLaptopStore()
+	filter := &pb.Filter{
+		MaxPriceUsd: 5000,
+	}
+
+	laptop := sample.NewLaptop()
+	err := store.Save(laptop)
+	require.NoError(t, err)
+
+	other := sample.NewLaptop()
+	err = store.Save(other)
+	require.NoError(t, err)
+
+	_, err = store.Undelete(laptop.Id, 0)
+	require.ErrorIs(t, err, service.ErrNotDeleted)
+
+	err = store.Delete(laptop.Id, 0)
+	require.NoError(t, err)
+
+	found, err := store.Find(laptop.Id)
+	require.NoError(t, err)
+	require.Nil(t, found)
+
+	require.Equal(t, []string{other.Id}, searchIDs(t, store, &service.SearchQuery{Filter: filter}))
+	require.Equal(t, []string{laptop.Id, other.Id}, searchIDs(t, store, &service.SearchQuery{Filter: filter, ShowDeleted: true}))
+
+	facets, err := store.Facets(filter, service.HistogramWidths{})
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), facets.Total)
+
+	// deleted laptops cannot be changed or saved again
+	err = store.Delete(laptop.Id, 0)
+	require.ErrorIs(t, err, service.ErrNotFound)
+	err = store.Update(laptop, 0)
+	require.ErrorIs(t, err, service.ErrNotFound)
+	err = store.Save(laptop)
+	require.ErrorIs(t, err, service.ErrAlreadyExists)
+
+	_, err = store.Undelete(laptop.Id, laptop.Revision)
+	require.ErrorIs(t, err, service.ErrRevisionMismatch)
+
+	restored, err := store.Undelete(laptop.Id, 0)
+	require.NoError(t, err)
+	require.Nil(t, restored.DeletedAt)
+	require.Greater(t, restored.Revision, other.Revision)
+
+	found, err = store.Find(laptop.Id)
+	require.NoError(t, err)
+	require.Equal(t, restored.Revision, found.Revision)
+	require.Equal(t, []string{laptop.Id, other.Id}, searchIDs(t, store, &service.SearchQuery{Filter: filter}))
+}
+
+func TestInMemoryLaptopStorePurge(t *testing.T){
+	t.Parallel()
+
+	store := service.NewInMemoryLaptopStore()
+	filter := &pb.Filter{
+		MaxPriceUsd: 5000,
+	}
+
+	laptop := sample.NewLaptop()
+	err := store.Save(laptop)
+	require.NoError(t, err)
+
+	other := sample.NewLaptop()
+	err = store.Save(other)
+	require.NoError(t, err)
+
+	err = store.Delete(laptop.Id, 0)
+	require.NoError(t, err)
+
+	// the laptop was deleted after the cutoff
+	purged, err := store.Purge(time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.Empty(t, purged)
+
+	purged, err = store.Purge(time.Now().Add(time.Second))
+	require.NoError(t, err)
+	require.Equal(t, []string{laptop.Id}, purged)
+
+	require.Equal(t, []string{other.Id}, searchIDs(t, store, &service.SearchQuery{Filter: filter, ShowDeleted: true}))
+
+	_, err = store.Undelete(laptop.Id, 0)
+	require.ErrorIs(t, err, service.ErrNotFound)
+
+	// the ID is free again once the laptop is purged
+	err = store.Save(laptop)
+	require.NoError(t, err)
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"fmt"
+	"gRPC/pb"
+	"math"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+// laptopValidator collects the field violations of a laptop
+type laptopValidator struct {
+	violations []*errdetails.BadRequest_FieldViolation
+}
+
+func (validator *laptopValidator) add(field string, format string, args ...interface{}) {
+	validator.violations = append(validator.violations, &errdetails.BadRequest_FieldViolation{
+		Field:       field,
+		Description: fmt.Sprintf(format, args...),
+	})
+}
+
+// validateLaptop checks the laptop and its nested messages. The fields of the
+// returned violations are prefixed with field, the path of the laptop in the request.
+func validateLaptop(field string, laptop *pb.Laptop) []*errdetails.BadRequest_FieldViolation {
+	validator := &laptopValidator{}
+	validator.laptop(field, laptop)
+	return validator.violations
+}
+
+func (validator *laptopValidator) laptop(field string, laptop *pb.Laptop) {
+	validator.text(field+".brand", laptop.GetBrand())
+	validator.text(field+".name", laptop.GetName())
+
+	if laptop.GetCpu() == nil {
+		validator.add(field+".cpu", "is required")
+	} else {
+		validator.cpu(field+".cpu", laptop.GetCpu())
+	}
+
+	if laptop.GetRam() == nil {
+		validator.add(field+".ram", "is required")
+	} else {
+		validator.memory(field+".ram", laptop.GetRam())
+	}
+
+	for i, gpu := range laptop.GetGpus() {
+		validator.gpu(fmt.Sprintf("%s.gpus[%d]", field, i), gpu)
+	}
+
+	for i, storage := range laptop.GetStorages() {
+		validator.storage(fmt.Sprintf("%s.storages[%d]", field, i), storage)
+	}
+
+	if laptop.GetScreen() == nil {
+		validator.add(field+".screen", "is required")
+	} else {
+		validator.screen(field+".screen", laptop.GetScreen())
+	}
+
+	switch weight := laptop.GetWeight().(type) {
+	case *pb.Laptop_WeightKg:
+		validator.positive(field+".weight_kg", weight.WeightKg)
+	case *pb.Laptop_WeightLb:
+		validator.positive(field+".weight_lb", weight.WeightLb)
+	}
+
+	if laptop.GetPriceUsd() < 0 || math.IsNaN(laptop.GetPriceUsd()) || math.IsInf(laptop.GetPriceUsd(), 0) {
+		validator.add(field+".price_usd", "must not be negative")
+	}
+}
+
+func (validator *laptopValidator) cpu(field string, cpu *pb.CPU) {
+	validator.text(field+".brand", cpu.GetBrand())
+	validator.text(field+".name", cpu.GetName())
+
+	if cpu.GetNumberCores() == 0 {
+		validator.add(field+".number_cores", "must be positive")
+	}
+	if cpu.GetNumberThreads() < cpu.GetNumberCores() {
+		validator.add(field+".number_threads", "must not be less than number_cores")
+	}
+
+	validator.frequencies(field, cpu.GetMinGhz(), cpu.GetMaxGhz())
+}
+
+func (validator *laptopValidator) gpu(field string, gpu *pb.GPU) {
+	validator.text(field+".brand", gpu.GetBrand())
+	validator.text(field+".name", gpu.GetName())
+
+	// GPUs may leave out their number of cores and threads
+	if gpu.GetNumberThreads() > 0 && gpu.GetNumberThreads() < gpu.GetNumberCores() {
+		validator.add(field+".number_threads", "must not be less than number_cores")
+	}
+
+	validator.frequencies(field, gpu.GetMinGhz(), gpu.GetMaxGhz())
+
+	if gpu.GetMemory() == nil {
+		validator.add(field+".memory", "is required")
+	} else {
+		validator.memory(field+".memory", gpu.GetMemory())
+	}
+}
+
+func (validator *laptopValidator) frequencies(field string, minGhz float64, maxGhz float64) {
+	validator.positive(field+".min_ghz", minGhz)
+	if maxGhz < minGhz || math.IsNaN(maxGhz) {
+		validator.add(field+".max_ghz", "must not be less than min_ghz")
+	}
+}
+
+func (validator *laptopValidator) memory(field string, memory *pb.Memory) {
+	if memory.GetValue() == 0 {
+		validator.add(field+".value", "must be positive")
+	}
+	if memory.GetUnit() == pb.Memory_UNKNOWN {
+		validator.add(field+".unit", "must be set")
+	} else if _, ok := pb.Memory_Unit_name[int32(memory.GetUnit())]; !ok {
+		validator.add(field+".unit", "is unknown")
+	}
+}
+
+func (validator *laptopValidator) storage(field string, storage *pb.Storage) {
+	if storage.GetDriver() == pb.Storage_UNKNOWN {
+		validator.add(field+".driver", "must be set")
+	} else if _, ok := pb.Storage_Driver_name[int32(storage.GetDriver())]; !ok {
+		validator.add(field+".driver", "is unknown")
+	}
+
+	if storage.GetMemory() == nil {
+		validator.add(field+".memory", "is required")
+	} else {
+		validator.memory(field+".memory", storage.GetMemory())
+	}
+}
+
+func (validator *laptopValidator) screen(field string, screen *pb.Screen) {
+	validator.positive(field+".size_inch", float64(screen.GetSizeInch()))
+
+	if screen.GetResolution() == nil {
+		validator.add(field+".resolution", "is required")
+	} else {
+		if screen.GetResolution().GetWidth() == 0 {
+			validator.add(field+".resolution.width", "must be positive")
+		}
+		if screen.GetResolution().GetHeight() == 0 {
+			validator.add(field+".resolution.height", "must be positive")
+		}
+	}
+}
+
+func (validator *laptopValidator) text(field string, value string) {
+	if strings.TrimSpace(value) == "" {
+		validator.add(field, "must not be empty")
+	}
+}
+
+func (validator *laptopValidator) positive(field string, value float64) {
+	if !(value > 0) || math.IsInf(value, 0) {
+		validator.add(field, "must be positive")
+	}
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gRPC/pb"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+)
+
+var ErrWatchOverflow = errors.New("watch fell too far behind the store")
+var ErrChangesTruncated = errors.New("changes are no longer in the change log")
+
+// defaultChangeLogSize is the number of changes kept by the in-memory stores
+const defaultChangeLogSize = 10000
+
+// LaptopWatch delivers the laptops created or updated in a store after the watch started
+type LaptopWatch interface {
+	// Next blocks until the next laptop is written or ctx is done.
+	// It returns ErrWatchOverflow if the watch could not keep up with the writes.
+	Next(ctx context.Context) (*pb.Laptop, error)
+	// Close stops the watch, it must be called once the watch is no longer used
+	Close()
+}
+
+// LaptopChangeWatch delivers the changes of a store in sequence order
+type LaptopChangeWatch interface {
+	// Next blocks until the next change is made or ctx is done. It returns
+	// ErrChangesTruncated if the change was dropped from the change log
+	// before the watch read it.
+	Next(ctx context.Context) (*pb.LaptopChange, error)
+	// Close stops the watch, it must be called once the watch is no longer used
+	Close()
+}
+
+// changeLog keeps the latest changes of a store, numbered by a store-wide sequence.
+// Writers append to it while holding the store mutex, watches read it with its
+// own mutex so that they never block writers while sending changes.
+type changeLog struct {
+	mutex    sync.RWMutex
+	capacity int
+	// changes holds the changes with sequences from last-len(changes)+1 to last
+	changes []*pb.LaptopChange
+	last    uint64
+	// appended is closed and replaced whenever a change is appended
+	appended chan struct{}
+}
+
+func newChangeLog(capacity int) *changeLog {
+	return &changeLog{
+		capacity: capacity,
+		appended: make(chan struct{}),
+	}
+}
+
+// append records a change, taking ownership of the laptops which must not be modified afterwards
+func (log *changeLog) append(changeType pb.LaptopChange_Type, before *pb.Laptop, after *pb.Laptop) {
+	log.mutex.Lock()
+	defer log.mutex.Unlock()
+
+	log.last++
+	log.changes = append(log.changes, &pb.LaptopChange{
+		Sequence: log.last,
+		Type:     changeType,
+		Before:   before,
+		After:    after,
+	})
+	if len(log.changes) > log.capacity {
+		log.changes = log.changes[len(log.changes)-log.capacity:]
+	}
+
+	close(log.appended)
+	log.appended = make(chan struct{})
+}
+
+// oldest returns the sequence of the oldest change still in the log
+func (log *changeLog) oldest() uint64 {
+	return log.last - uint64(len(log.changes)) + 1
+}
+
+// get returns the change with the given sequence, or a channel that is closed
+// once more changes are appended if the change was not made yet
+func (log *changeLog) get(sequence uint64) (*pb.LaptopChange, <-chan struct{}, error) {
+	log.mutex.RLock()
+	defer log.mutex.RUnlock()
+
+	if sequence > log.last {
+		return nil, log.appended, nil
+	}
+	if sequence < log.oldest() {
+		return nil, nil, fmt.Errorf("%w: change %d is older than change %d", ErrChangesTruncated, sequence, log.oldest())
+	}
+
+	return log.changes[sequence-log.oldest()], nil, nil
+}
+
+// watch starts watching the changes with a sequence greater than afterSequence
+func (log *changeLog) watch(afterSequence uint64) (*laptopChangeWatch, error) {
+	log.mutex.RLock()
+	defer log.mutex.RUnlock()
+
+	if afterSequence > log.last {
+		return nil, fmt.Errorf("%w: change %d was not made yet", ErrChangesTruncated, afterSequence)
+	}
+	if afterSequence+1 < log.oldest() {
+		return nil, fmt.Errorf("%w: change %d is older than change %d", ErrChangesTruncated, afterSequence+1, log.oldest())
+	}
+
+	return &laptopChangeWatch{log: log, next: afterSequence + 1}, nil
+}
+
+// watchLatest starts watching the changes made from now on
+func (log *changeLog) watchLatest() *laptopChangeWatch {
+	log.mutex.RLock()
+	defer log.mutex.RUnlock()
+
+	return &laptopChangeWatch{log: log, next: log.last + 1}
+}
+
+type laptopChangeWatch struct {
+	log  *changeLog
+	next uint64
+}
+
+func (watch *laptopChangeWatch) Next(ctx context.Context) (*pb.LaptopChange, error) {
+	for {
+		change, appended, err := watch.log.get(watch.next)
+		if err != nil {
+			return nil, err
+		}
+		if change != nil {
+			watch.next++
+			return proto.Clone(change).(*pb.LaptopChange), nil
+		}
+
+		select {
+		case <-appended:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (watch *laptopChangeWatch) Close() {
+}
+
+// laptopWatch follows a change watch, skipping deletions
+type laptopWatch struct {
+	changes LaptopChangeWatch
+}
+
+func (watch *laptopWatch) Next(ctx context.Context) (*pb.Laptop, error) {
+	for {
+		change, err := watch.changes.Next(ctx)
+		if errors.Is(err, ErrChangesTruncated) {
+			return nil, ErrWatchOverflow
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if change.GetAfter() != nil {
+			return change.GetAfter(), nil
+		}
+	}
+}
+
+func (watch *laptopWatch) Close() {
+	watch.changes.Close()
+}
@@ -4,12 +4,19 @@ import (
 	"context"
 	"gRPC/pb"
 	"gRPC/sample"
+	"bytes"
 	"gRPC/service"
+	"math"
+	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func TestServerCreateLaptop(t *testing.T){
@@ -56,6 +63,12 @@ func TestServerCreateLaptop(t *testing.T){
 			store: storeDuplicateID,
 			code: codes.AlreadyExists,
 		},
+		{
+			name:"failure_no_laptop",
+			laptop: nil,
+			store: service.NewInMemoryLaptopStore(),
+			code: codes.InvalidArgument,
+		},
 	}
 
 	for i:=range testCases{
@@ -84,4 +97,758 @@ func TestServerCreateLaptop(t *testing.T){
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestServerCreateLaptopValidation(t *testing.T){
+	t.Parallel()
+
+	testCases := []struct{
+		name string
+		change func(laptop *pb.Laptop)
+		fields []string
+	}{
+		{
+			name: "negative_price",
+			change: func(laptop *pb.Laptop) {
+				laptop.PriceUsd = -1
+			},
+			fields: []string{"latop.price_usd"},
+		},
+		{
+			name: "empty_brand",
+			change: func(laptop *pb.Laptop) {
+				laptop.Brand = " "
+			},
+			fields: []string{"latop.brand"},
+		},
+		{
+			name: "cpu",
+			change: func(laptop *pb.Laptop) {
+				laptop.Cpu.NumberCores = 0
+				laptop.Cpu.MinGhz = 3
+				laptop.Cpu.MaxGhz = 2
+			},
+			fields: []string{"latop.cpu.number_cores", "latop.cpu.max_ghz"},
+		},
+		{
+			name: "cpu_threads",
+			change: func(laptop *pb.Laptop) {
+				laptop.Cpu.NumberCores = 8
+				laptop.Cpu.NumberThreads = 4
+			},
+			fields: []string{"latop.cpu.number_threads"},
+		},
+		{
+			name: "memory_units",
+			change: func(laptop *pb.Laptop) {
+				laptop.Ram.Unit = pb.Memory_UNKNOWN
+				laptop.Gpus[0].Memory.Value = 0
+				laptop.Storages[1].Driver = pb.Storage_UNKNOWN
+			},
+			fields: []string{"latop.ram.unit", "latop.gpus[0].memory.value", "latop.storages[1].driver"},
+		},
+		{
+			name: "screen",
+			change: func(laptop *pb.Laptop) {
+				laptop.Screen.Resolution = &pb.Screen_Resolution{}
+				laptop.Weight = &pb.Laptop_WeightKg{WeightKg: 0}
+			},
+			fields: []string{"latop.screen.resolution.width", "latop.screen.resolution.height", "latop.weight_kg"},
+		},
+		{
+			name: "missing_messages",
+			change: func(laptop *pb.Laptop) {
+				laptop.Cpu = nil
+				laptop.Ram = nil
+				laptop.Screen = nil
+			},
+			fields: []string{"latop.cpu", "latop.ram", "latop.screen"},
+		},
+	}
+
+	for i := range testCases{
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			laptop := sample.NewLaptop()
+			tc.change(laptop)
+
+			server := service.NewLaptopServer(service.NewInMemoryLaptopStore(), nil, nil)
+			_, err := server.CreateLaptop(context.Background(), &pb.CreateLatopRequest{Latop: laptop})
+			require.Equal(t, codes.InvalidArgument, status.Code(err))
+			require.ElementsMatch(t, tc.fields, violatedFields(t, err))
+		})
+	}
+}
+
+// violatedFields returns the fields of the BadRequest details of the error
+func violatedFields(t *testing.T, err error) []string{
+	details := service.ParseErrorDetails(err)
+	require.Equal(t, service.ReasonInvalidArgument, details.Reason())
+
+	fields := []string{}
+	for _, violation := range details.FieldViolations{
+		fields = append(fields, violation.GetField())
+	}
+
+	return fields
+}
+
+func TestServerWriteValidation(t *testing.T){
+	t.Parallel()
+
+	store := service.NewInMemoryLaptopStore()
+	server := service.NewLaptopServer(store, nil, nil)
+	ctx := context.Background()
+
+	laptop := sample.NewLaptop()
+	err := store.Save(laptop)
+	require.NoError(t, err)
+
+	invalid := proto.Clone(laptop).(*pb.Laptop)
+	invalid.Name = ""
+	_, err = server.UpdateLaptop(ctx, &pb.UpdateLaptopRequest{Laptop: invalid})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Equal(t, []string{"laptop.name"}, violatedFields(t, err))
+
+	// the patched laptop is validated, not only the patch
+	patchReq := &pb.PatchLaptopRequest{
+		Laptop: &pb.Laptop{Id: laptop.Id, Cpu: &pb.CPU{MaxGhz: laptop.Cpu.MinGhz / 2}},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"cpu.max_ghz"}},
+	}
+	_, err = server.PatchLaptop(ctx, patchReq)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Equal(t, []string{"laptop.cpu.max_ghz"}, violatedFields(t, err))
+
+	other, err := store.Find(laptop.Id)
+	require.NoError(t, err)
+	require.Equal(t, laptop.Revision, other.Revision)
+
+	_, err = server.CreateLaptop(ctx, &pb.CreateLatopRequest{Latop: invalid, Upsert: true})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestServerUpsertLaptop(t *testing.T){
+	t.Parallel()
+
+	laptopStore := service.NewInMemoryLaptopStore()
+	server := service.NewLaptopServer(laptopStore, nil, nil)
+
+	laptop := sample.NewLaptop()
+	laptop.UpdatedAt = nil
+	res, err := server.CreateLaptop(context.Background(), &pb.CreateLatopRequest{Latop: laptop, Upsert: true})
+	require.NoError(t, err)
+	require.Equal(t, laptop.Id, res.Id)
+	require.False(t, res.Replaced)
+
+	replacement := sample.NewLaptop()
+	replacement.Id = laptop.Id
+	replacement.UpdatedAt = nil
+	res, err = server.CreateLaptop(context.Background(), &pb.CreateLatopRequest{Latop: replacement, Upsert: true})
+	require.NoError(t, err)
+	require.True(t, res.Replaced)
+	require.Greater(t, res.Revision, laptop.Revision)
+
+	other, err := laptopStore.Find(laptop.Id)
+	require.NoError(t, err)
+	require.Equal(t, replacement.Name, other.Name)
+	require.Equal(t, res.Revision, other.Revision)
+	require.NotNil(t, other.UpdatedAt)
+
+	_, err = server.CreateLaptop(context.Background(), &pb.CreateLatopRequest{Latop: replacement})
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+}
+
+func TestServerGetLaptop(t *testing.T){
+	t.Parallel()
+
+	store := service.NewInMemoryLaptopStore()
+	laptop := sample.NewLaptop()
+	err := store.Save(laptop)
+	require.NoError(t, err)
+
+	testCases := []struct{
+		name string
+		id string
+		code codes.Code
+	}{
+		{
+			name: "success",
+			id: laptop.Id,
+			code: codes.OK,
+		},
+		{
+			name: "failure_invalid_id",
+			id: "invalid-id",
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "failure_not_found",
+			id: sample.NewLaptop().Id,
+			code: codes.NotFound,
+		},
+	}
+
+	for i := range testCases{
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := service.NewLaptopServer(store, nil, nil)
+			res, err := server.GetLaptop(context.Background(), &pb.GetLaptopRequest{Id: tc.id})
+			if tc.code == codes.OK{
+				require.NoError(t, err)
+				require.NotNil(t, res)
+				requireSameLaptop(t, laptop, res.Laptop)
+			}else{
+				require.Error(t, err)
+				require.Nil(t, res)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tc.code, st.Code())
+			}
+		})
+	}
+}
+
+func TestServerUpdateLaptop(t *testing.T){
+	t.Parallel()
+
+	store := service.NewInMemoryLaptopStore()
+	laptop := sample.NewLaptop()
+	err := store.Save(laptop)
+	require.NoError(t, err)
+
+	updated := sample.NewLaptop()
+	updated.Id = laptop.Id
+
+	laptopInvalidID := sample.NewLaptop()
+	laptopInvalidID.Id = "invalid-id"
+
+	testCases := []struct{
+		name string
+		laptop *pb.Laptop
+		code codes.Code
+	}{
+		{
+			name: "success",
+			laptop: updated,
+			code: codes.OK,
+		},
+		{
+			name: "failure_nil_laptop",
+			laptop: nil,
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "failure_invalid_id",
+			laptop: laptopInvalidID,
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "failure_not_found",
+			laptop: sample.NewLaptop(),
+			code: codes.NotFound,
+		},
+	}
+
+	for i := range testCases{
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := service.NewLaptopServer(store, nil, nil)
+			res, err := server.UpdateLaptop(context.Background(), &pb.UpdateLaptopRequest{Laptop: tc.laptop})
+			if tc.code == codes.OK{
+				require.NoError(t, err)
+				require.NotNil(t, res)
+
+				other, err := store.Find(tc.laptop.Id)
+				require.NoError(t, err)
+				requireSameLaptop(t, res.Laptop, other)
+				require.Equal(t, tc.laptop.Name, other.Name)
+			}else{
+				require.Error(t, err)
+				require.Nil(t, res)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tc.code, st.Code())
+			}
+		})
+	}
+}
+
+func TestServerDeleteLaptop(t *testing.T){
+	t.Parallel()
+
+	store := service.NewInMemoryLaptopStore()
+	laptop := sample.NewLaptop()
+	err := store.Save(laptop)
+	require.NoError(t, err)
+
+	testCases := []struct{
+		name string
+		id string
+		code codes.Code
+	}{
+		{
+			name: "success",
+			id: laptop.Id,
+			code: codes.OK,
+		},
+		{
+			name: "failure_invalid_id",
+			id: "invalid-id",
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "failure_not_found",
+			id: sample.NewLaptop().Id,
+			code: codes.NotFound,
+		},
+	}
+
+	for i := range testCases{
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := service.NewLaptopServer(store, nil, nil)
+			res, err := server.DeleteLaptop(context.Background(), &pb.DeleteLaptopRequest{Id: tc.id})
+			if tc.code == codes.OK{
+				require.NoError(t, err)
+				require.NotNil(t, res)
+				require.Equal(t, tc.id, res.Id)
+
+				other, err := store.Find(tc.id)
+				require.NoError(t, err)
+				require.Nil(t, other)
+			}else{
+				require.Error(t, err)
+				require.Nil(t, res)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tc.code, st.Code())
+			}
+		})
+	}
+}
+
+func TestServerUndeleteLaptop(t *testing.T){
+	t.Parallel()
+
+	store := service.NewInMemoryLaptopStore()
+	laptop := sample.NewLaptop()
+	err := store.Save(laptop)
+	require.NoError(t, err)
+
+	server := service.NewLaptopServer(store, nil, nil)
+	_, err = server.UndeleteLaptop(context.Background(), &pb.UndeleteLaptopRequest{Id: laptop.Id})
+	details := service.ParseErrorDetails(err)
+	require.Equal(t, codes.FailedPrecondition, details.Code)
+	require.Equal(t, service.ReasonNotDeleted, details.Reason())
+
+	_, err = server.DeleteLaptop(context.Background(), &pb.DeleteLaptopRequest{Id: laptop.Id})
+	require.NoError(t, err)
+
+	res, err := server.UndeleteLaptop(context.Background(), &pb.UndeleteLaptopRequest{Id: laptop.Id})
+	require.NoError(t, err)
+	require.Equal(t, laptop.Id, res.Laptop.Id)
+	require.Nil(t, res.Laptop.DeletedAt)
+
+	_, err = server.UndeleteLaptop(context.Background(), &pb.UndeleteLaptopRequest{Id: sample.NewLaptop().Id})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = server.UndeleteLaptop(context.Background(), &pb.UndeleteLaptopRequest{Id: "invalid-id"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestServerPurgeDeletedLaptops(t *testing.T){
+	t.Parallel()
+
+	laptopStore := service.NewInMemoryLaptopStore()
+	imageFolder := t.TempDir()
+	imageStore := service.NewDiscImageStore(imageFolder)
+	ratingStore := service.NewInMemoryRatingStore()
+
+	laptop := sample.NewLaptop()
+	err := laptopStore.Save(laptop)
+	require.NoError(t, err)
+
+	_, err = imageStore.Save(laptop.Id, ".jpg", *bytes.NewBufferString("image"))
+	require.NoError(t, err)
+	_, err = ratingStore.Add(laptop.Id, 5)
+	require.NoError(t, err)
+
+	server := service.NewLaptopServer(laptopStore, imageStore, ratingStore)
+	_, err = server.DeleteLaptop(context.Background(), &pb.DeleteLaptopRequest{Id: laptop.Id})
+	require.NoError(t, err)
+
+	purged, err := server.PurgeDeletedLaptops(time.Hour)
+	require.NoError(t, err)
+	require.Empty(t, purged)
+
+	purged, err = server.PurgeDeletedLaptops(-time.Second)
+	require.NoError(t, err)
+	require.Equal(t, []string{laptop.Id}, purged)
+
+	images, err := os.ReadDir(imageFolder)
+	require.NoError(t, err)
+	require.Empty(t, images)
+
+	rating, err := ratingStore.Find(laptop.Id)
+	require.NoError(t, err)
+	require.Nil(t, rating)
+
+	found := 0
+	_, err = laptopStore.Search(&service.SearchQuery{Filter: &pb.Filter{MaxPriceUsd: 5000}, ShowDeleted: true}, func(*pb.Laptop) error {
+		found++
+		return nil
+	})
+	require.NoError(t, err)
+	require.Zero(t, found)
+}
+
+func TestServerPurgeDeletedLaptopsAfterRestart(t *testing.T){
+	t.Parallel()
+
+	laptopStore := service.NewInMemoryLaptopStore()
+	imageFolder := t.TempDir()
+
+	laptop := sample.NewLaptop()
+	err := laptopStore.Save(laptop)
+	require.NoError(t, err)
+	_, err = service.NewDiscImageStore(imageFolder).Save(laptop.Id, ".jpg", *bytes.NewBufferString("image"))
+	require.NoError(t, err)
+
+	// the image store of the restarted server finds the images saved before
+	server := service.NewLaptopServer(laptopStore, service.NewDiscImageStore(imageFolder), service.NewInMemoryRatingStore())
+	_, err = server.DeleteLaptop(context.Background(), &pb.DeleteLaptopRequest{Id: laptop.Id})
+	require.NoError(t, err)
+	purged, err := server.PurgeDeletedLaptops(-time.Second)
+	require.NoError(t, err)
+	require.Equal(t, []string{laptop.Id}, purged)
+
+	images, err := os.ReadDir(imageFolder)
+	require.NoError(t, err)
+	require.Empty(t, images)
+}
+
+func TestServerPurgeDeletedLaptopsFailure(t *testing.T){
+	t.Parallel()
+
+	laptopStore := service.NewInMemoryLaptopStore()
+	imageFolder := t.TempDir()
+	imageStore := service.NewDiscImageStore(imageFolder)
+	ratingStore, err := service.NewWALRatingStore(t.TempDir(), 0)
+	require.NoError(t, err)
+
+	server := service.NewLaptopServer(laptopStore, imageStore, ratingStore)
+	laptopIDs := []string{}
+	for i := 0; i < 2; i++{
+		laptop := sample.NewLaptop()
+		err = laptopStore.Save(laptop)
+		require.NoError(t, err)
+		_, err = imageStore.Save(laptop.Id, ".jpg", *bytes.NewBufferString("image"))
+		require.NoError(t, err)
+		_, err = server.DeleteLaptop(context.Background(), &pb.DeleteLaptopRequest{Id: laptop.Id})
+		require.NoError(t, err)
+		laptopIDs = append(laptopIDs, laptop.Id)
+	}
+
+	// deleting the ratings fails, the images of every purged laptop are deleted anyway
+	require.NoError(t, ratingStore.Close())
+	purged, err := server.PurgeDeletedLaptops(-time.Second)
+	require.Error(t, err)
+	require.ElementsMatch(t, laptopIDs, purged)
+	for _, laptopID := range laptopIDs{
+		require.Contains(t, err.Error(), laptopID)
+	}
+
+	images, err := os.ReadDir(imageFolder)
+	require.NoError(t, err)
+	require.Empty(t, images)
+}
+
+func TestServerRunPurgeDisabled(t *testing.T){
+	t.Parallel()
+
+	server := service.NewLaptopServer(service.NewInMemoryLaptopStore(), nil, nil)
+	for _, interval := range []time.Duration{0, -time.Second}{
+		// RunPurge would block until the context is done if purging was not disabled
+		server.RunPurge(context.Background(), time.Hour, interval)
+	}
+}
+
+func TestServerPatchLaptop(t *testing.T){
+	t.Parallel()
+
+	testCases := []struct{
+		name string
+		patch func(id string) *pb.Laptop
+		paths []string
+		code codes.Code
+		check func(t *testing.T, before *pb.Laptop, after *pb.Laptop)
+	}{
+		{
+			name: "success_price",
+			patch: func(id string) *pb.Laptop {
+				return &pb.Laptop{Id: id, PriceUsd: 1234, Name: "ignored"}
+			},
+			paths: []string{"price_usd"},
+			code: codes.OK,
+			check: func(t *testing.T, before *pb.Laptop, after *pb.Laptop) {
+				require.Equal(t, float64(1234), after.PriceUsd)
+				require.Equal(t, before.Name, after.Name)
+				require.True(t, proto.Equal(before.Cpu, after.Cpu))
+			},
+		},
+		{
+			name: "success_nested",
+			patch: func(id string) *pb.Laptop {
+				return &pb.Laptop{Id: id, Cpu: &pb.CPU{MaxGhz: 4.9, Name: "ignored"}}
+			},
+			paths: []string{"cpu.max_ghz"},
+			code: codes.OK,
+			check: func(t *testing.T, before *pb.Laptop, after *pb.Laptop) {
+				require.Equal(t, 4.9, after.Cpu.MaxGhz)
+				require.Equal(t, before.Cpu.Name, after.Cpu.Name)
+				require.Equal(t, before.Cpu.NumberCores, after.Cpu.NumberCores)
+			},
+		},
+		{
+			name: "success_oneof",
+			patch: func(id string) *pb.Laptop {
+				return &pb.Laptop{Id: id, Weight: &pb.Laptop_WeightLb{WeightLb: 4.2}}
+			},
+			paths: []string{"weight"},
+			code: codes.OK,
+			check: func(t *testing.T, before *pb.Laptop, after *pb.Laptop) {
+				require.Equal(t, 4.2, after.GetWeightLb())
+				require.Zero(t, after.GetWeightKg())
+			},
+		},
+		{
+			name: "success_ram",
+			patch: func(id string) *pb.Laptop {
+				return &pb.Laptop{Id: id, Ram: &pb.Memory{Value: 128, Unit: pb.Memory_GIGABYTE}}
+			},
+			paths: []string{"ram"},
+			code: codes.OK,
+			check: func(t *testing.T, before *pb.Laptop, after *pb.Laptop) {
+				require.Equal(t, uint64(128), after.Ram.Value)
+				require.Equal(t, before.Brand, after.Brand)
+			},
+		},
+		{
+			name: "failure_unknown_path",
+			patch: func(id string) *pb.Laptop {
+				return &pb.Laptop{Id: id}
+			},
+			paths: []string{"cpu.unknown"},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "failure_immutable_path",
+			patch: func(id string) *pb.Laptop {
+				return &pb.Laptop{Id: id}
+			},
+			paths: []string{"id"},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "failure_deleted_at",
+			patch: func(id string) *pb.Laptop {
+				return &pb.Laptop{Id: id, DeletedAt: timestamppb.Now()}
+			},
+			paths: []string{"deleted_at"},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "failure_deleted_at_sub_path",
+			patch: func(id string) *pb.Laptop {
+				return &pb.Laptop{Id: id, DeletedAt: timestamppb.Now()}
+			},
+			paths: []string{"deleted_at.seconds"},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "failure_empty_mask",
+			patch: func(id string) *pb.Laptop {
+				return &pb.Laptop{Id: id}
+			},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "failure_not_found",
+			patch: func(id string) *pb.Laptop {
+				return &pb.Laptop{Id: sample.NewLaptop().Id}
+			},
+			paths: []string{"price_usd"},
+			code: codes.NotFound,
+		},
+	}
+
+	for i := range testCases{
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := service.NewInMemoryLaptopStore()
+			laptop := sample.NewLaptop()
+			err := store.Save(laptop)
+			require.NoError(t, err)
+
+			req := &pb.PatchLaptopRequest{
+				Laptop: tc.patch(laptop.Id),
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: tc.paths},
+			}
+
+			server := service.NewLaptopServer(store, nil, nil)
+			res, err := server.PatchLaptop(context.Background(), req)
+			if tc.code == codes.OK{
+				require.NoError(t, err)
+				require.NotNil(t, res)
+
+				other, err := store.Find(laptop.Id)
+				require.NoError(t, err)
+				requireSameLaptop(t, res.Laptop, other)
+				require.True(t, other.UpdatedAt.AsTime().After(laptop.UpdatedAt.AsTime()))
+				tc.check(t, laptop, other)
+			}else{
+				require.Error(t, err)
+				require.Nil(t, res)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tc.code, st.Code())
+			}
+		})
+	}
+}
+
+func TestServerLaptopRevision(t *testing.T){
+	t.Parallel()
+
+	store := service.NewInMemoryLaptopStore()
+	server := service.NewLaptopServer(store, nil, nil)
+	ctx := context.Background()
+
+	laptop := sample.NewLaptop()
+	createRes, err := server.CreateLaptop(ctx, &pb.CreateLatopRequest{Latop: laptop})
+	require.NoError(t, err)
+	require.NotZero(t, createRes.Revision)
+
+	getRes, err := server.GetLaptop(ctx, &pb.GetLaptopRequest{Id: laptop.Id})
+	require.NoError(t, err)
+	require.Equal(t, createRes.Revision, getRes.Laptop.Revision)
+	created := getRes.Laptop.Revision
+
+	read := proto.Clone(getRes.Laptop)
+
+	// first writer wins
+	updateRes, err := server.UpdateLaptop(ctx, &pb.UpdateLaptopRequest{Laptop: getRes.Laptop, ExpectedRevision: created})
+	require.NoError(t, err)
+	require.Greater(t, updateRes.Laptop.Revision, created)
+	// the laptop read from the store is not changed by the update
+	require.True(t, proto.Equal(read, getRes.Laptop))
+
+	// second writer still holds the old revision
+	_, err = server.UpdateLaptop(ctx, &pb.UpdateLaptopRequest{Laptop: laptop, ExpectedRevision: created})
+	require.Equal(t, codes.Aborted, status.Code(err))
+
+	patchReq := &pb.PatchLaptopRequest{
+		Laptop: &pb.Laptop{Id: laptop.Id, PriceUsd: 1000},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"price_usd"}},
+		ExpectedRevision: created,
+	}
+	_, err = server.PatchLaptop(ctx, patchReq)
+	require.Equal(t, codes.Aborted, status.Code(err))
+
+	patchReq.ExpectedRevision = updateRes.Laptop.Revision
+	patchRes, err := server.PatchLaptop(ctx, patchReq)
+	require.NoError(t, err)
+	require.Greater(t, patchRes.Laptop.Revision, updateRes.Laptop.Revision)
+
+	_, err = server.DeleteLaptop(ctx, &pb.DeleteLaptopRequest{Id: laptop.Id, ExpectedRevision: updateRes.Laptop.Revision})
+	require.Equal(t, codes.Aborted, status.Code(err))
+
+	_, err = server.DeleteLaptop(ctx, &pb.DeleteLaptopRequest{Id: laptop.Id, ExpectedRevision: patchRes.Laptop.Revision})
+	require.NoError(t, err)
+}
+
+func TestServerSearchFacets(t *testing.T){
+	t.Parallel()
+
+	store := service.NewInMemoryLaptopStore()
+
+	newLaptop := func(brand string, gpuBrands []string, price float64, ramGB uint64, year uint32) {
+		laptop := sample.NewLaptop()
+		laptop.Brand = brand
+		laptop.Cpu.Brand = "Intel"
+		laptop.Gpus = nil
+		for _, gpuBrand := range gpuBrands{
+			laptop.Gpus = append(laptop.Gpus, &pb.GPU{Brand: gpuBrand})
+		}
+		laptop.Storages = []*pb.Storage{sample.NewSSD(), sample.NewSSD()}
+		laptop.Keyboard.Layout = pb.Keyboard_US
+		laptop.Screen.Pannel = pb.Screen_IPS
+		laptop.PriceUsd = price
+		laptop.Ram = &pb.Memory{Value: ramGB, Unit: pb.Memory_GIGABYTE}
+		laptop.ReleaseYear = year
+		err := store.Save(laptop)
+		require.NoError(t, err)
+	}
+
+	newLaptop("Dell", []string{"Nvidia", "Nvidia"}, 1200, 8, 2018)
+	newLaptop("Dell", []string{"AMD"}, 1800, 16, 2019)
+	newLaptop("Lenovo", []string{"Nvidia", "AMD"}, 1900, 12, 2019)
+	newLaptop("Apple", nil, 4000, 32, 2019)
+
+	server := service.NewLaptopServer(store, nil, nil)
+	res, err := server.SearchFacets(context.Background(), &pb.SearchFacetsRequest{
+		Filter: &pb.Filter{MaxPriceUsd: 3000},
+		PriceUsdBucketWidth: 1000,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, uint32(3), res.Total)
+	require.Equal(t, []*pb.FacetCount{{Value: "Dell", Count: 2}, {Value: "Lenovo", Count: 1}}, facetValues(res.Brands))
+	require.Equal(t, []*pb.FacetCount{{Value: "AMD", Count: 2}, {Value: "Nvidia", Count: 2}}, facetValues(res.GpuBrands))
+	require.Equal(t, []*pb.FacetCount{{Value: "Intel", Count: 3}}, facetValues(res.CpuBrands))
+	require.Equal(t, []*pb.FacetCount{{Value: "US", Count: 3}}, facetValues(res.KeyboardLayouts))
+	require.Equal(t, []*pb.FacetCount{{Value: "IPS", Count: 3}}, facetValues(res.ScreenPannels))
+	require.Equal(t, []*pb.FacetCount{{Value: "SSD", Count: 3}}, facetValues(res.StorageDrivers))
+
+	require.Equal(t, [][3]float64{{1000, 2000, 3}}, bucketValues(res.PriceUsd))
+	require.Equal(t, [][3]float64{{8, 16, 2}, {16, 24, 1}}, bucketValues(res.RamGb))
+	require.Equal(t, [][3]float64{{2018, 2019, 1}, {2019, 2020, 2}}, bucketValues(res.ReleaseYear))
+
+	for _, width := range []float64{-1, 1e-300, math.NaN(), math.Inf(1)}{
+		_, err = server.SearchFacets(context.Background(), &pb.SearchFacetsRequest{PriceUsdBucketWidth: width})
+		require.Equal(t, codes.InvalidArgument, status.Code(err), "width %v", width)
+		_, err = server.SearchFacets(context.Background(), &pb.SearchFacetsRequest{RamGbBucketWidth: width})
+		require.Equal(t, codes.InvalidArgument, status.Code(err), "width %v", width)
+	}
+}
+
+func facetValues(facets []*pb.FacetCount) []*pb.FacetCount {
+	values := []*pb.FacetCount{}
+	for _, facet := range facets{
+		values = append(values, &pb.FacetCount{Value: facet.Value, Count: facet.Count})
+	}
+	return values
+}
+
+func bucketValues(buckets []*pb.HistogramBucket) [][3]float64 {
+	values := [][3]float64{}
+	for _, bucket := range buckets{
+		values = append(values, [3]float64{bucket.Min, bucket.Max, float64(bucket.Count)})
+	}
+	return values
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gRPC/pb"
+)
+
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// errPageTokenEncoding is returned when the next page token of a search cannot be created
+var errPageTokenEncoding = errors.New("cannot encode page token")
+
+// pageToken points right after the last laptop of a page.
+// The ordering is kept so that a token cannot be reused with a different order.
+type pageToken struct {
+	Field      pb.OrderBy_Field `json:"f,omitempty"`
+	Descending bool             `json:"d,omitempty"`
+	Key        float64          `json:"k,omitempty"`
+	Sequence   uint64           `json:"s"`
+}
+
+// encodePageToken returns an opaque token pointing right after the given search
+// result. It fails if the key of the result is not finite.
+func encodePageToken(orderBy *pb.OrderBy, result *searchResult) (string, error) {
+	token := pageToken{
+		Field:      orderBy.GetField(),
+		Descending: orderBy.GetDescending(),
+		Key:        result.key,
+		Sequence:   result.sequence,
+	}
+
+	data, err := json.Marshal(token)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", errPageTokenEncoding, err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodePageToken returns the position stored in the token, or nil for an empty token
+func decodePageToken(token string, orderBy *pb.OrderBy) (*searchResult, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+
+	decoded := pageToken{}
+	err = json.Unmarshal(data, &decoded)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+
+	if decoded.Field != orderBy.GetField() || decoded.Descending != orderBy.GetDescending() {
+		return nil, ErrInvalidPageToken
+	}
+
+	result := &searchResult{
+		key:      decoded.Key,
+		sequence: decoded.Sequence,
+	}
+	return result, nil
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"gRPC/pb"
+	"sync"
+	"time"
+)
+
+// laptopPersister saves the laptops of a persistentLaptopStore
+type laptopPersister interface {
+	// persist saves the new versions of the laptops, with the sequences in
+	// which they were created, before they are stored in memory
+	persist(laptops []*pb.Laptop, sequences []uint64) error
+	// persistRemoved forgets the laptops with the IDs and creation sequences before they are removed from memory
+	persistRemoved(laptopIDs []string, sequences []uint64) error
+	// committed is called once a persisted write is stored in memory
+	committed()
+}
+
+// persistentLaptopStore is an InMemoryLaptopStore that hands every laptop it
+// writes to a persister before storing it. A write that cannot be persisted
+// fails without changing the store, so readers and watchers only see the
+// writes that were persisted.
+type persistentLaptopStore struct {
+	*InMemoryLaptopStore
+
+	// mutex serializes the writes, so that the persister runs without the lock
+	// of the in-memory store and the store holds the last write when the
+	// persister is told it is committed
+	mutex     sync.Mutex
+	persister laptopPersister
+}
+
+func newPersistentLaptopStore(store *InMemoryLaptopStore, persister laptopPersister) *persistentLaptopStore {
+	store.persister = persister
+	return &persistentLaptopStore{
+		InMemoryLaptopStore: store,
+		persister:           persister,
+	}
+}
+
+// persist hands the new versions of the laptops to the persister of the store,
+// if any. The laptops that are not stored yet get the next sequences, in the
+// order in which they are inserted afterwards. The mutex must be locked, see
+// unlockWhilePersisting.
+func (store *InMemoryLaptopStore) persist(laptops ...*pb.Laptop) error {
+	if store.persister == nil {
+		return nil
+	}
+
+	sequences := make([]uint64, len(laptops))
+	next := store.sequence
+	for i, laptop := range laptops {
+		sequence, ok := store.sequences[laptop.Id]
+		if !ok {
+			next++
+			sequence = next
+		}
+		sequences[i] = sequence
+	}
+
+	defer store.unlockWhilePersisting()()
+	return store.persister.persist(laptops, sequences)
+}
+
+// persistRemoved hands the IDs of the purged laptops to the persister of the
+// store, if any. The mutex must be locked, see unlockWhilePersisting.
+func (store *InMemoryLaptopStore) persistRemoved(laptopIDs ...string) error {
+	if store.persister == nil {
+		return nil
+	}
+
+	sequences := make([]uint64, len(laptopIDs))
+	for i, laptopID := range laptopIDs {
+		sequences[i] = store.sequences[laptopID]
+	}
+
+	defer store.unlockWhilePersisting()()
+	return store.persister.persistRemoved(laptopIDs, sequences)
+}
+
+// unlockWhilePersisting unlocks the mutex of the store and returns the function
+// locking it again, so that readers do not wait for the persister. The writes
+// to a store with a persister are serialized by its persistentLaptopStore, so
+// no other write changes the store meanwhile and readers see the previous state
+// until the write is stored in memory.
+func (store *InMemoryLaptopStore) unlockWhilePersisting() func() {
+	store.mutex.Unlock()
+	return store.mutex.Lock
+}
+
+func (store *persistentLaptopStore) Save(laptop *pb.Laptop) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	err := store.InMemoryLaptopStore.Save(laptop)
+	if err != nil {
+		return err
+	}
+
+	store.persister.committed()
+	return nil
+}
+
+func (store *persistentLaptopStore) SaveAll(laptops []*pb.Laptop) []error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	errs := store.InMemoryLaptopStore.SaveAll(laptops)
+	for _, err := range errs {
+		if err != nil {
+			return errs
+		}
+	}
+
+	store.persister.committed()
+	return errs
+}
+
+func (store *persistentLaptopStore) Update(laptop *pb.Laptop, expectedRevision uint64) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	err := store.InMemoryLaptopStore.Update(laptop, expectedRevision)
+	if err != nil {
+		return err
+	}
+
+	store.persister.committed()
+	return nil
+}
+
+func (store *persistentLaptopStore) Upsert(laptop *pb.Laptop) (bool, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	created, err := store.InMemoryLaptopStore.Upsert(laptop)
+	if err != nil {
+		return false, err
+	}
+
+	store.persister.committed()
+	return created, nil
+}
+
+func (store *persistentLaptopStore) Patch(ID string, patch *pb.Laptop, paths []string, expectedRevision uint64) (*pb.Laptop, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	laptop, err := store.InMemoryLaptopStore.Patch(ID, patch, paths, expectedRevision)
+	if err != nil {
+		return nil, err
+	}
+
+	store.persister.committed()
+	return laptop, nil
+}
+
+func (store *persistentLaptopStore) Delete(ID string, expectedRevision uint64) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	err := store.InMemoryLaptopStore.Delete(ID, expectedRevision)
+	if err != nil {
+		return err
+	}
+
+	store.persister.committed()
+	return nil
+}
+
+func (store *persistentLaptopStore) Undelete(ID string, expectedRevision uint64) (*pb.Laptop, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	laptop, err := store.InMemoryLaptopStore.Undelete(ID, expectedRevision)
+	if err != nil {
+		return nil, err
+	}
+
+	store.persister.committed()
+	return laptop, nil
+}
+
+func (store *persistentLaptopStore) Purge(deletedBefore time.Time) ([]string, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	purged, err := store.InMemoryLaptopStore.Purge(deletedBefore)
+	if err != nil {
+		return nil, err
+	}
+
+	store.persister.committed()
+	return purged, nil
+}
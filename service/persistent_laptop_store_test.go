@@ -0,0 +1,58 @@
+package service
+
+import (
+	"gRPC/pb"
+	"gRPC/sample"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// blockingPersister blocks every write until it is released
+type blockingPersister struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (persister *blockingPersister) persist([]*pb.Laptop, []uint64) error {
+	persister.started <- struct{}{}
+	<-persister.release
+	return nil
+}
+
+func (persister *blockingPersister) persistRemoved([]string, []uint64) error {
+	persister.started <- struct{}{}
+	<-persister.release
+	return nil
+}
+
+func (persister *blockingPersister) committed() {}
+
+func TestPersistentLaptopStoreReadWhilePersisting(t *testing.T) {
+	t.Parallel()
+
+	persister := &blockingPersister{
+		started: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+	store := newPersistentLaptopStore(NewInMemoryLaptopStore(), persister)
+
+	laptop := sample.NewLaptop()
+	saved := make(chan error)
+	go func() {
+		saved <- store.Save(laptop)
+	}()
+	<-persister.started
+
+	// the laptop is not stored before it is persisted, and reading does not wait for it
+	other, err := store.Find(laptop.Id)
+	require.NoError(t, err)
+	require.Nil(t, other)
+
+	close(persister.release)
+	require.NoError(t, <-saved)
+
+	other, err = store.Find(laptop.Id)
+	require.NoError(t, err)
+	require.NotNil(t, other)
+}
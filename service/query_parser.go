@@ -0,0 +1,359 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"gRPC/pb"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var ErrInvalidQuery = errors.New("invalid query")
+
+// QueryError reports where a search query could not be parsed
+type QueryError struct {
+	// Column is the 1-based position of the offending character
+	Column  int
+	Message string
+}
+
+func (err *QueryError) Error() string {
+	return fmt.Sprintf("%v at column %d: %s", ErrInvalidQuery, err.Column, err.Message)
+}
+
+func (err *QueryError) Unwrap() error {
+	return ErrInvalidQuery
+}
+
+// memoryUnits maps the unit suffixes of memory literals, in upper case, to their unit
+var memoryUnits = map[string]pb.Memory_Unit{
+	"BIT": pb.Memory_BIT,
+	"B":   pb.Memory_BYTE,
+	"KB":  pb.Memory_KILOBYTE,
+	"MB":  pb.Memory_MEGABYTE,
+	"GB":  pb.Memory_GIGABYTE,
+	"TB":  pb.Memory_TERABYTE,
+}
+
+var queryOperators = map[string]pb.Predicate_Operator{
+	":":  pb.Predicate_EQUAL,
+	"=":  pb.Predicate_EQUAL,
+	"!=": pb.Predicate_NOT_EQUAL,
+	"<":  pb.Predicate_LESS,
+	"<=": pb.Predicate_LESS_OR_EQUAL,
+	">":  pb.Predicate_GREATER,
+	">=": pb.Predicate_GREATER_OR_EQUAL,
+}
+
+type queryTokenKind int
+
+const (
+	wordToken queryTokenKind = iota
+	quotedToken
+	operatorToken
+	openToken
+	closeToken
+	endToken
+)
+
+type queryToken struct {
+	kind   queryTokenKind
+	text   string
+	column int
+}
+
+// parseQuery parses a search query such as `brand:Dell price_usd<2000 ram>=16GB`
+// into a filter expression. Terms are combined with AND unless separated by OR,
+// NOT negates the next term and parentheses group terms.
+func parseQuery(query string) (*pb.FilterExpression, error) {
+	tokens, err := tokenizeQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := &queryParser{tokens: tokens}
+	expression, err := parser.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	token := parser.peek()
+	if token.kind != endToken {
+		return nil, &QueryError{Column: token.column, Message: fmt.Sprintf("unexpected %q", token.text)}
+	}
+
+	return expression, nil
+}
+
+func tokenizeQuery(query string) ([]queryToken, error) {
+	tokens := []queryToken{}
+	runes := []rune(query)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		column := i + 1
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, queryToken{kind: openToken, text: "(", column: column})
+			i++
+		case r == ')':
+			tokens = append(tokens, queryToken{kind: closeToken, text: ")", column: column})
+			i++
+		case r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, &QueryError{Column: column, Message: "unterminated quoted value"}
+			}
+
+			tokens = append(tokens, queryToken{kind: quotedToken, text: string(runes[i+1 : end]), column: column})
+			i = end + 1
+		case isOperatorRune(r):
+			end := i + 1
+			if end < len(runes) && runes[end] == '=' && r != ':' && r != '=' {
+				end++
+			}
+
+			text := string(runes[i:end])
+			if _, ok := queryOperators[text]; !ok {
+				return nil, &QueryError{Column: column, Message: fmt.Sprintf("unknown operator %q", text)}
+			}
+
+			tokens = append(tokens, queryToken{kind: operatorToken, text: text, column: column})
+			i = end
+		default:
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && !isOperatorRune(runes[end]) && !strings.ContainsRune(`()"`, runes[end]) {
+				end++
+			}
+
+			tokens = append(tokens, queryToken{kind: wordToken, text: string(runes[i:end]), column: column})
+			i = end
+		}
+	}
+
+	tokens = append(tokens, queryToken{kind: endToken, text: "end of query", column: len(runes) + 1})
+	return tokens, nil
+}
+
+func isOperatorRune(r rune) bool {
+	return strings.ContainsRune(":=!<>", r)
+}
+
+type queryParser struct {
+	tokens   []queryToken
+	position int
+	// depth is the number of NOT and groups around the current term. It is
+	// limited while parsing, as a query nested deeply enough would overflow
+	// the stack before the expression could be checked.
+	depth int
+}
+
+func (parser *queryParser) peek() queryToken {
+	return parser.tokens[parser.position]
+}
+
+func (parser *queryParser) next() queryToken {
+	token := parser.tokens[parser.position]
+	if token.kind != endToken {
+		parser.position++
+	}
+	return token
+}
+
+func (parser *queryParser) isKeyword(keyword string) bool {
+	token := parser.peek()
+	return token.kind == wordToken && token.text == keyword
+}
+
+func (parser *queryParser) parseOr() (*pb.FilterExpression, error) {
+	expressions := []*pb.FilterExpression{}
+
+	for {
+		expression, err := parser.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		expressions = append(expressions, expression)
+
+		if !parser.isKeyword("OR") {
+			break
+		}
+		parser.next()
+	}
+
+	if len(expressions) == 1 {
+		return expressions[0], nil
+	}
+
+	return &pb.FilterExpression{
+		Node: &pb.FilterExpression_Or{Or: &pb.FilterExpression_List{Expressions: expressions}},
+	}, nil
+}
+
+func (parser *queryParser) parseAnd() (*pb.FilterExpression, error) {
+	expressions := []*pb.FilterExpression{}
+
+	for {
+		expression, err := parser.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		expressions = append(expressions, expression)
+
+		if parser.isKeyword("AND") {
+			parser.next()
+			continue
+		}
+
+		token := parser.peek()
+		if token.kind == endToken || token.kind == closeToken || parser.isKeyword("OR") {
+			break
+		}
+	}
+
+	if len(expressions) == 1 {
+		return expressions[0], nil
+	}
+
+	return &pb.FilterExpression{
+		Node: &pb.FilterExpression_And{And: &pb.FilterExpression_List{Expressions: expressions}},
+	}, nil
+}
+
+func (parser *queryParser) parseUnary() (*pb.FilterExpression, error) {
+	token := parser.peek()
+	if parser.isKeyword("NOT") || token.kind == openToken {
+		if parser.depth == maxExpressionDepth {
+			return nil, &QueryError{Column: token.column, Message: fmt.Sprintf("query is nested deeper than %d levels", maxExpressionDepth)}
+		}
+
+		parser.depth++
+		defer func() { parser.depth-- }()
+	}
+
+	if parser.isKeyword("NOT") {
+		parser.next()
+
+		expression, err := parser.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return &pb.FilterExpression{
+			Node: &pb.FilterExpression_Not{Not: expression},
+		}, nil
+	}
+
+	if token.kind == openToken {
+		parser.next()
+
+		expression, err := parser.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		closing := parser.next()
+		if closing.kind != closeToken {
+			return nil, &QueryError{Column: closing.column, Message: fmt.Sprintf("expected \")\" but got %q", closing.text)}
+		}
+
+		return expression, nil
+	}
+
+	return parser.parseTerm()
+}
+
+func (parser *queryParser) parseTerm() (*pb.FilterExpression, error) {
+	fieldToken := parser.next()
+	if fieldToken.kind != wordToken {
+		return nil, &QueryError{Column: fieldToken.column, Message: fmt.Sprintf("expected a field but got %q", fieldToken.text)}
+	}
+
+	field := laptopFields[fieldToken.text]
+	if field == nil {
+		return nil, &QueryError{Column: fieldToken.column, Message: fmt.Sprintf("unknown field %q", fieldToken.text)}
+	}
+
+	opToken := parser.next()
+	operator, ok := queryOperators[opToken.text]
+	if opToken.kind != operatorToken || !ok {
+		return nil, &QueryError{Column: opToken.column, Message: fmt.Sprintf("expected an operator after %q but got %q", fieldToken.text, opToken.text)}
+	}
+
+	ordered := operator != pb.Predicate_EQUAL && operator != pb.Predicate_NOT_EQUAL
+	if ordered && (field.texts != nil || field.flags != nil) {
+		return nil, &QueryError{Column: opToken.column, Message: fmt.Sprintf("field %q cannot be compared with %q", fieldToken.text, opToken.text)}
+	}
+
+	valueToken := parser.next()
+	if valueToken.kind != wordToken && valueToken.kind != quotedToken {
+		return nil, &QueryError{Column: valueToken.column, Message: fmt.Sprintf("expected a value but got %q", valueToken.text)}
+	}
+
+	predicate := &pb.Predicate{
+		Field:    fieldToken.text,
+		Operator: operator,
+	}
+
+	switch {
+	case field.texts != nil:
+		predicate.Value = &pb.Predicate_Text{Text: valueToken.text}
+	case field.numbers != nil:
+		number, err := strconv.ParseFloat(valueToken.text, 64)
+		// ParseFloat accepts NaN and infinities, which cannot be compared
+		if err != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+			return nil, &QueryError{Column: valueToken.column, Message: fmt.Sprintf("%q is not a finite number", valueToken.text)}
+		}
+		predicate.Value = &pb.Predicate_Number{Number: number}
+	case field.flags != nil:
+		flag, err := strconv.ParseBool(valueToken.text)
+		if err != nil {
+			return nil, &QueryError{Column: valueToken.column, Message: fmt.Sprintf("%q is not true or false", valueToken.text)}
+		}
+		predicate.Value = &pb.Predicate_Flag{Flag: flag}
+	case field.memories != nil:
+		memory, err := parseMemory(valueToken.text)
+		if err != nil {
+			return nil, &QueryError{Column: valueToken.column, Message: err.Error()}
+		}
+		predicate.Value = &pb.Predicate_Memory{Memory: memory}
+	}
+
+	return &pb.FilterExpression{
+		Node: &pb.FilterExpression_Predicate{Predicate: predicate},
+	}, nil
+}
+
+// parseMemory parses a memory literal such as 16GB, 512mb or 2TERABYTE
+func parseMemory(text string) (*pb.Memory, error) {
+	digits := strings.IndexFunc(text, func(r rune) bool {
+		return !unicode.IsDigit(r)
+	})
+	if digits <= 0 {
+		return nil, fmt.Errorf("%q is not a memory size like 16GB", text)
+	}
+
+	value, err := strconv.ParseUint(text[:digits], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a memory size like 16GB", text)
+	}
+
+	suffix := strings.ToUpper(text[digits:])
+	unit, ok := memoryUnits[suffix]
+	if !ok {
+		unit = pb.Memory_Unit(pb.Memory_Unit_value[suffix])
+	}
+	if unit == pb.Memory_UNKNOWN {
+		return nil, fmt.Errorf("%q has an unknown memory unit", text)
+	}
+
+	return &pb.Memory{Value: value, Unit: unit}, nil
+}
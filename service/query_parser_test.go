@@ -0,0 +1,142 @@
+package service
+
+import (
+	"gRPC/pb"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestParseQuery(t *testing.T) {
+	t.Parallel()
+
+	predicate := func(field string, operator pb.Predicate_Operator, value interface{}) *pb.FilterExpression {
+		p := &pb.Predicate{Field: field, Operator: operator}
+		switch v := value.(type) {
+		case string:
+			p.Value = &pb.Predicate_Text{Text: v}
+		case float64:
+			p.Value = &pb.Predicate_Number{Number: v}
+		case bool:
+			p.Value = &pb.Predicate_Flag{Flag: v}
+		case *pb.Memory:
+			p.Value = &pb.Predicate_Memory{Memory: v}
+		}
+		return &pb.FilterExpression{Node: &pb.FilterExpression_Predicate{Predicate: p}}
+	}
+	and := func(expressions ...*pb.FilterExpression) *pb.FilterExpression {
+		return &pb.FilterExpression{Node: &pb.FilterExpression_And{And: &pb.FilterExpression_List{Expressions: expressions}}}
+	}
+	or := func(expressions ...*pb.FilterExpression) *pb.FilterExpression {
+		return &pb.FilterExpression{Node: &pb.FilterExpression_Or{Or: &pb.FilterExpression_List{Expressions: expressions}}}
+	}
+	not := func(expression *pb.FilterExpression) *pb.FilterExpression {
+		return &pb.FilterExpression{Node: &pb.FilterExpression_Not{Not: expression}}
+	}
+
+	testCases := []struct {
+		name     string
+		query    string
+		expected *pb.FilterExpression
+	}{
+		{
+			name:  "implicit_and",
+			query: "brand:Dell price_usd<2000 ram>=16GB gpu.brand:Nvidia",
+			expected: and(
+				predicate("brand", pb.Predicate_EQUAL, "Dell"),
+				predicate("price_usd", pb.Predicate_LESS, 2000.0),
+				predicate("ram", pb.Predicate_GREATER_OR_EQUAL, &pb.Memory{Value: 16, Unit: pb.Memory_GIGABYTE}),
+				predicate("gpu.brand", pb.Predicate_EQUAL, "Nvidia"),
+			),
+		},
+		{
+			name:  "or_not_and_groups",
+			query: `(brand:Dell OR brand="Lenovo") AND NOT keyboard.backlit=false`,
+			expected: and(
+				or(
+					predicate("brand", pb.Predicate_EQUAL, "Dell"),
+					predicate("brand", pb.Predicate_EQUAL, "Lenovo"),
+				),
+				not(predicate("keyboard.backlit", pb.Predicate_EQUAL, false)),
+			),
+		},
+		{
+			name:     "quoted_value",
+			query:    `name:"Thinkpad X1"`,
+			expected: predicate("name", pb.Predicate_EQUAL, "Thinkpad X1"),
+		},
+		{
+			name:     "memory_units",
+			query:    "ssd>512mb hdd<=2terabyte",
+			expected: and(
+				predicate("ssd", pb.Predicate_GREATER, &pb.Memory{Value: 512, Unit: pb.Memory_MEGABYTE}),
+				predicate("hdd", pb.Predicate_LESS_OR_EQUAL, &pb.Memory{Value: 2, Unit: pb.Memory_TERABYTE}),
+			),
+		},
+		{
+			name:     "not_equal",
+			query:    "cpu.number_cores!=4",
+			expected: predicate("cpu.number_cores", pb.Predicate_NOT_EQUAL, 4.0),
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			expression, err := parseQuery(tc.query)
+			require.NoError(t, err)
+			require.True(t, proto.Equal(tc.expected, expression), "got %v", expression)
+
+			_, err = compileExpression(expression)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestParseQueryError(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		query  string
+		column int
+	}{
+		{name: "empty", query: "", column: 1},
+		{name: "unknown_field", query: "brand:Dell color:red", column: 12},
+		{name: "missing_operator", query: "brand Dell", column: 7},
+		{name: "unknown_operator", query: "price_usd!2000", column: 10},
+		{name: "missing_value", query: "price_usd<", column: 11},
+		{name: "not_a_number", query: "price_usd<cheap", column: 11},
+		{name: "nan", query: "price_usd<NaN", column: 11},
+		{name: "infinity", query: "brand:Dell price_usd<=Infinity", column: 23},
+		{name: "negative_infinity", query: "price_usd>-Inf", column: 11},
+		{name: "unknown_unit", query: "ram>=16XB", column: 6},
+		{name: "memory_without_unit", query: "ram>=16", column: 6},
+		{name: "ordered_text", query: "brand>Dell", column: 6},
+		{name: "unclosed_group", query: "(brand:Dell", column: 12},
+		{name: "unexpected_close", query: "brand:Dell)", column: 11},
+		{name: "unterminated_quote", query: `name:"Thinkpad`, column: 6},
+		{name: "deep_groups", query: strings.Repeat("(", 100000) + "brand:Dell", column: maxExpressionDepth + 1},
+		{name: "deep_not", query: strings.Repeat("NOT ", 100000) + "brand:Dell", column: 4*maxExpressionDepth + 1},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := parseQuery(tc.query)
+			require.ErrorIs(t, err, ErrInvalidQuery)
+
+			queryError, ok := err.(*QueryError)
+			require.True(t, ok)
+			require.Equal(t, tc.column, queryError.Column, queryError.Message)
+		})
+	}
+}
@@ -4,6 +4,8 @@ import "sync"
 
 type RatingStore interface {
 	Add(laptopID string, score float64) (*Rating, error)
+	Find(laptopID string) (*Rating, error)
+	Delete(laptopID string) error
 }
 
 type Rating struct {
@@ -38,5 +40,31 @@ func (store *InMemoryRatingStore) Add(laptopID string, score float64) (*Rating,
 	}
 
 	store.rating[laptopID] = rating
-	return rating, nil
+	return &Rating{
+		Count: rating.Count,
+		Sum: rating.Sum,
+	}, nil
+}
+
+func (store *InMemoryRatingStore) Find(laptopID string) (*Rating, error){
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	rating := store.rating[laptopID]
+	if rating == nil{
+		return nil, nil
+	}
+
+	return &Rating{
+		Count: rating.Count,
+		Sum: rating.Sum,
+	}, nil
+}
+
+func (store *InMemoryRatingStore) Delete(laptopID string) error{
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	delete(store.rating, laptopID)
+	return nil
 }
\ No newline at end of file
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorDomain is the domain of the ErrorInfo details attached to LaptopServer errors
+const ErrorDomain = "laptop.service"
+
+// reasons of the ErrorInfo details attached to LaptopServer errors
+const (
+	ReasonNotFound         = "NOT_FOUND"
+	ReasonAlreadyExists    = "ALREADY_EXISTS"
+	ReasonInvalidArgument  = "INVALID_ARGUMENT"
+	ReasonRevisionMismatch = "REVISION_MISMATCH"
+	ReasonNotDeleted       = "NOT_DELETED"
+	ReasonImageTooLarge    = "IMAGE_TOO_LARGE"
+	ReasonChangesTruncated = "CHANGES_TRUNCATED"
+	ReasonWatchOverflow    = "WATCH_OVERFLOW"
+	ReasonStreamFailure    = "STREAM_FAILURE"
+	ReasonStorageFailure   = "STORAGE_FAILURE"
+)
+
+// resource types of the ResourceInfo details
+const (
+	laptopResource = "laptop"
+	imageResource  = "image"
+)
+
+// withDetails returns the error of the status with the details attached
+func withDetails(st *status.Status, details ...proto.Message) error {
+	detailed, err := st.WithDetails(details...)
+	if err != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
+
+func errorInfo(reason string, metadata map[string]string) *errdetails.ErrorInfo {
+	return &errdetails.ErrorInfo{
+		Reason:   reason,
+		Domain:   ErrorDomain,
+		Metadata: metadata,
+	}
+}
+
+// reasonError returns an error with an ErrorInfo detail of the given reason
+func reasonError(code codes.Code, reason string, metadata map[string]string, format string, args ...interface{}) error {
+	return withDetails(status.Newf(code, format, args...), errorInfo(reason, metadata))
+}
+
+// internalError reports an unexpected failure of a store or of the stream
+func internalError(reason string, format string, args ...interface{}) error {
+	return reasonError(codes.Internal, reason, nil, format, args...)
+}
+
+// notFoundError reports that a resource does not exist
+func notFoundError(resourceType string, name string) error {
+	st := status.Newf(codes.NotFound, "%s %s does not exist", resourceType, name)
+	return withDetails(st,
+		errorInfo(ReasonNotFound, map[string]string{"resource_type": resourceType}),
+		&errdetails.ResourceInfo{
+			ResourceType: resourceType,
+			ResourceName: name,
+			Description:  "does not exist",
+		},
+	)
+}
+
+// alreadyExistsError reports that a resource cannot be created because its name is taken
+func alreadyExistsError(resourceType string, name string) error {
+	st := status.Newf(codes.AlreadyExists, "%s %s already exists", resourceType, name)
+	return withDetails(st,
+		errorInfo(ReasonAlreadyExists, map[string]string{"resource_type": resourceType}),
+		&errdetails.ResourceInfo{
+			ResourceType: resourceType,
+			ResourceName: name,
+			Description:  "already exists",
+		},
+	)
+}
+
+// invalidFieldError reports a single invalid field of the request
+func invalidFieldError(field string, format string, args ...interface{}) error {
+	return badRequestError([]*errdetails.BadRequest_FieldViolation{{
+		Field:       field,
+		Description: fmt.Sprintf(format, args...),
+	}})
+}
+
+// badRequestError returns an InvalidArgument error with the violations attached as BadRequest details
+func badRequestError(violations []*errdetails.BadRequest_FieldViolation) error {
+	descriptions := make([]string, len(violations))
+	for i, violation := range violations {
+		descriptions[i] = violation.GetField() + " " + violation.GetDescription()
+	}
+
+	st := status.New(codes.InvalidArgument, "invalid request: "+strings.Join(descriptions, ", "))
+	return withDetails(st,
+		errorInfo(ReasonInvalidArgument, nil),
+		&errdetails.BadRequest{FieldViolations: violations},
+	)
+}
+
+// laptopStoreError converts an error of the laptop store about the given laptop
+func laptopStoreError(err error, laptopID string, action string) error {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return notFoundError(laptopResource, laptopID)
+	case errors.Is(err, ErrAlreadyExists):
+		return alreadyExistsError(laptopResource, laptopID)
+	case errors.Is(err, ErrRevisionMismatch):
+		st := status.Newf(codes.Aborted, "cannot %s laptop: %v", action, err)
+		return withDetails(st,
+			errorInfo(ReasonRevisionMismatch, nil),
+			&errdetails.ResourceInfo{
+				ResourceType: laptopResource,
+				ResourceName: laptopID,
+				Description:  "was changed since the expected revision",
+			},
+		)
+	case errors.Is(err, ErrNotDeleted):
+		st := status.Newf(codes.FailedPrecondition, "cannot %s laptop: %v", action, err)
+		return withDetails(st,
+			errorInfo(ReasonNotDeleted, nil),
+			&errdetails.ResourceInfo{
+				ResourceType: laptopResource,
+				ResourceName: laptopID,
+				Description:  "is not deleted",
+			},
+		)
+	case errors.Is(err, ErrInvalidFieldMask):
+		return invalidFieldError("update_mask", "%v", err)
+	default:
+		return internalError(ReasonStorageFailure, "cannot %s laptop: %v", action, err)
+	}
+}
+
+// searchError converts an error of the laptop store returned while searching
+func searchError(ctx context.Context, err error) error {
+	switch {
+	case ctx.Err() != nil:
+		return contextError(ctx)
+	case errors.Is(err, ErrInvalidPageToken):
+		return invalidFieldError("page_token", "%v", err)
+	case errors.Is(err, ErrInvalidExpression):
+		return invalidFieldError("expression", "%v", err)
+	case errors.Is(err, errPageTokenEncoding):
+		return internalError(ReasonStorageFailure, "cannot create next page token: %v", err)
+	default:
+		return internalError(ReasonStorageFailure, "cannot search laptops: %v", err)
+	}
+}
+
+// changesError converts an error of the laptop store about its change log
+func changesError(err error) error {
+	if errors.Is(err, ErrChangesTruncated) {
+		return reasonError(codes.OutOfRange, ReasonChangesTruncated, nil, "%v", err)
+	}
+
+	return internalError(ReasonStorageFailure, "cannot watch changes: %v", err)
+}
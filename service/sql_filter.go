@@ -0,0 +1,125 @@
+package service
+
+import (
+	"gRPC/pb"
+	"math"
+	"strings"
+)
+
+// sqlBits returns the size of the memory in bits as an SQL integer, which is signed
+func sqlBits(bits uint64) int64 {
+	if bits > math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return int64(bits)
+}
+
+// folded returns the text used for comparisons ignoring case
+func folded(text string) string {
+	return strings.ToLower(text)
+}
+
+// sqlFilter translates the filter into conditions on the columns of the laptops
+// table, selecting the same laptops as isQualified. The conditions are joined
+// with AND and use ? placeholders for the returned arguments.
+func sqlFilter(filter *pb.Filter) ([]string, []interface{}) {
+	conditions := []string{}
+	args := []interface{}{}
+	add := func(condition string, values ...interface{}) {
+		conditions = append(conditions, condition)
+		args = append(args, values...)
+	}
+
+	add("price_usd <= ?", filter.GetMaxPriceUsd())
+	if filter.GetMinPriceUsd() != 0 {
+		add("price_usd >= ?", filter.GetMinPriceUsd())
+	}
+	if filter.GetMinCpuCores() > 0 {
+		add("cpu_number_cores >= ?", filter.GetMinCpuCores())
+	}
+	if filter.GetMinCpuGhz() != 0 {
+		add("cpu_min_ghz >= ?", filter.GetMinCpuGhz())
+	}
+	if toBit(filter.GetMinRam()) > 0 {
+		add("ram_bits >= ?", sqlBits(toBit(filter.GetMinRam())))
+	}
+
+	if len(filter.GetBrands()) > 0 {
+		add("brand_folded IN ("+placeholders(len(filter.GetBrands()))+")", foldedValues(filter.GetBrands())...)
+	}
+	if len(filter.GetNames()) > 0 {
+		add("name_folded IN ("+placeholders(len(filter.GetNames()))+")", foldedValues(filter.GetNames())...)
+	}
+
+	if filter.GetMinReleaseYear() > 0 {
+		add("release_year >= ?", filter.GetMinReleaseYear())
+	}
+	if filter.GetMaxReleaseYear() > 0 {
+		add("release_year <= ?", filter.GetMaxReleaseYear())
+	}
+
+	minGpuMemory := sqlBits(toBit(filter.GetMinGpuMemory()))
+	if filter.GetGpuBrand() != "" {
+		add("EXISTS (SELECT 1 FROM laptop_gpus WHERE laptop_gpus.laptop_id = laptops.id AND laptop_gpus.brand_folded = ? AND laptop_gpus.memory_bits >= ?)", folded(filter.GetGpuBrand()), minGpuMemory)
+	} else if minGpuMemory > 0 {
+		add("EXISTS (SELECT 1 FROM laptop_gpus WHERE laptop_gpus.laptop_id = laptops.id AND laptop_gpus.memory_bits >= ?)", minGpuMemory)
+	}
+
+	if toBit(filter.GetMinSsd()) > 0 {
+		add("ssd_bits >= ?", sqlBits(toBit(filter.GetMinSsd())))
+	}
+	if toBit(filter.GetMinHdd()) > 0 {
+		add("hdd_bits >= ?", sqlBits(toBit(filter.GetMinHdd())))
+	}
+
+	if filter.GetMinScreenSizeInch() != 0 {
+		add("screen_size_inch >= ?", float64(filter.GetMinScreenSizeInch()))
+	}
+	if filter.GetMaxScreenSizeInch() > 0 {
+		add("screen_size_inch <= ?", float64(filter.GetMaxScreenSizeInch()))
+	}
+	if filter.GetMinScreenResolution().GetWidth() > 0 {
+		add("screen_width >= ?", filter.GetMinScreenResolution().GetWidth())
+	}
+	if filter.GetMinScreenResolution().GetHeight() > 0 {
+		add("screen_height >= ?", filter.GetMinScreenResolution().GetHeight())
+	}
+	if filter.GetScreenPannel() != pb.Screen_UNKNOWN {
+		add("screen_pannel = ?", int32(filter.GetScreenPannel()))
+	}
+	if filter.GetRequireMultitouch() {
+		add("screen_multitouch")
+	}
+
+	if filter.GetKeyboardLayout() != pb.Keyboard_UNKNOWN {
+		add("keyboard_layout = ?", int32(filter.GetKeyboardLayout()))
+	}
+	if filter.GetRequireBacklit() {
+		add("keyboard_backlit")
+	}
+
+	maxWeightKg := 0.0
+	switch weight := filter.GetMaxWeight().(type) {
+	case *pb.Filter_MaxWeightKg:
+		maxWeightKg = weight.MaxWeightKg
+	case *pb.Filter_MaxWeightLb:
+		maxWeightKg = weight.MaxWeightLb * poundToKg
+	}
+	if maxWeightKg > 0 {
+		add("weight_kg <= ?", maxWeightKg)
+	}
+
+	return conditions, args
+}
+
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+func foldedValues(values []string) []interface{} {
+	args := make([]interface{}, len(values))
+	for i, value := range values {
+		args[i] = folded(value)
+	}
+	return args
+}
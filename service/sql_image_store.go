@@ -0,0 +1,97 @@
+package service
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// SQLImageStore is an ImageStore writing images to a folder and keeping their
+// metadata in a relational database
+type SQLImageStore struct {
+	db          *sql.DB
+	imageFolder string
+}
+
+// NewSQLImageStore returns a store using the database, whose schema is migrated first
+func NewSQLImageStore(db *sql.DB, imageFolder string) (*SQLImageStore, error) {
+	_, err := MigrateSQL(db)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SQLImageStore{
+		db:          db,
+		imageFolder: imageFolder,
+	}, nil
+}
+
+func (store *SQLImageStore) Save(laptopID string, imageType string, imageData bytes.Buffer) (string, error) {
+	imageID, imagePath, err := writeImageFile(store.imageFolder, laptopID, imageType, imageData)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = store.db.Exec(`INSERT INTO images (id, laptop_id, type, path) VALUES (?, ?, ?, ?)`, imageID, laptopID, imageType, imagePath)
+	if err != nil {
+		os.Remove(imagePath)
+		return "", fmt.Errorf("cannot insert image: %w", err)
+	}
+
+	return imageID, nil
+}
+
+// Find returns the metadata of the image, or nil if there is none
+func (store *SQLImageStore) Find(imageID string) (*ImageInfo, error) {
+	info := &ImageInfo{}
+	err := store.db.QueryRow(`SELECT laptop_id, type, path FROM images WHERE id = ?`, imageID).Scan(&info.LaptopID, &info.Type, &info.Path)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot select image: %w", err)
+	}
+
+	return info, nil
+}
+
+func (store *SQLImageStore) DeleteLaptopImages(laptopID string) (int, error) {
+	rows, err := store.db.Query(`SELECT id, path FROM images WHERE laptop_id = ?`, laptopID)
+	if err != nil {
+		return 0, fmt.Errorf("cannot select images: %w", err)
+	}
+
+	images := map[string]string{}
+	for rows.Next() {
+		imageID, imagePath := "", ""
+		err = rows.Scan(&imageID, &imagePath)
+		if err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("cannot scan image: %w", err)
+		}
+		images[imageID] = imagePath
+	}
+	rows.Close()
+	err = rows.Err()
+	if err != nil {
+		return 0, fmt.Errorf("cannot select images: %w", err)
+	}
+
+	deleted := 0
+	for imageID, imagePath := range images {
+		err = os.Remove(imagePath)
+		if err != nil && !os.IsNotExist(err) {
+			return deleted, fmt.Errorf("cannot delete image %s: %w", imageID, err)
+		}
+
+		_, err = store.db.Exec(`DELETE FROM images WHERE id = ?`, imageID)
+		if err != nil {
+			return deleted, fmt.Errorf("cannot delete image %s: %w", imageID, err)
+		}
+		deleted++
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,526 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"gRPC/pb"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// SQLLaptopStore is a LaptopStore backed by a relational database. Each laptop
+// is kept as a protobuf blob together with the columns that filters are
+// translated to and facets are counted from. The SQL is written for SQLite
+// and uses ? placeholders.
+//
+// Changes are only watched, and the text index only updated, for the writes
+// made through the store, so a database must not be shared by stores that are
+// watched or searched by text.
+type SQLLaptopStore struct {
+	*txLaptopStore
+	db *sql.DB
+}
+
+// querier is implemented by both *sql.DB and *sql.Tx
+type querier interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// NewSQLLaptopStore returns a store using the database, whose schema is migrated first
+func NewSQLLaptopStore(db *sql.DB) (*SQLLaptopStore, error) {
+	_, err := MigrateSQL(db)
+	if err != nil {
+		return nil, err
+	}
+
+	store := &SQLLaptopStore{db: db}
+	store.txLaptopStore, err = newTxLaptopStore(store.transact)
+	if err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+// transact runs the function in a database transaction
+func (store *SQLLaptopStore) transact(transaction func(tx laptopTx) error) error {
+	tx, err := store.db.Begin()
+	if err != nil {
+		return fmt.Errorf("cannot begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = transaction(sqlLaptopTx{tx})
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("cannot commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// sqlLaptopTx is a laptopTx in a database transaction
+type sqlLaptopTx struct {
+	tx *sql.Tx
+}
+
+func (tx sqlLaptopTx) get(ID string) (*pb.Laptop, error) {
+	return selectLaptop(tx.tx, ID)
+}
+
+func (tx sqlLaptopTx) nextRevision() (uint64, error) {
+	return nextCounter(tx.tx, "revision")
+}
+
+func (tx sqlLaptopTx) insert(laptop *pb.Laptop) error {
+	return insertLaptop(tx.tx, laptop)
+}
+
+func (tx sqlLaptopTx) update(old *pb.Laptop, laptop *pb.Laptop) error {
+	return updateLaptop(tx.tx, laptop)
+}
+
+func (tx sqlLaptopTx) remove(laptop *pb.Laptop) error {
+	err := deleteGPUs(tx.tx, laptop.GetId())
+	if err != nil {
+		return err
+	}
+
+	err = deleteStorages(tx.tx, laptop.GetId())
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.tx.Exec(`DELETE FROM laptops WHERE id = ?`, laptop.GetId())
+	if err != nil {
+		return fmt.Errorf("cannot delete laptop: %w", err)
+	}
+
+	return nil
+}
+
+func (tx sqlLaptopTx) deletedBefore(deletedBefore time.Time) ([]*pb.Laptop, error) {
+	laptops, _, err := selectLaptops(tx.tx, `SELECT sequence, data FROM laptops WHERE deleted_at < ? ORDER BY sequence`, deletedBefore.UnixNano())
+	return laptops, err
+}
+
+func (tx sqlLaptopTx) all() ([]*pb.Laptop, error) {
+	laptops, _, err := selectLaptops(tx.tx, `SELECT sequence, data FROM laptops ORDER BY sequence`)
+	return laptops, err
+}
+
+// nextCounter increments the counter of the laptops table and returns its new value
+func nextCounter(tx *sql.Tx, name string) (uint64, error) {
+	_, err := tx.Exec(`UPDATE laptop_counters SET value = value + 1 WHERE name = ?`, name)
+	if err != nil {
+		return 0, fmt.Errorf("cannot increment %s: %w", name, err)
+	}
+
+	value := uint64(0)
+	err = tx.QueryRow(`SELECT value FROM laptop_counters WHERE name = ?`, name).Scan(&value)
+	if err != nil {
+		return 0, fmt.Errorf("cannot read %s: %w", name, err)
+	}
+
+	return value, nil
+}
+
+// selectLaptop returns the stored laptop, deleted or not, or nil if there is none
+func selectLaptop(q querier, ID string) (*pb.Laptop, error) {
+	data := []byte{}
+	err := q.QueryRow(`SELECT data FROM laptops WHERE id = ?`, ID).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot select laptop: %w", err)
+	}
+
+	return unmarshalLaptop(data)
+}
+
+func unmarshalLaptop(data []byte) (*pb.Laptop, error) {
+	laptop := &pb.Laptop{}
+	err := proto.Unmarshal(data, laptop)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode laptop: %w", err)
+	}
+
+	return laptop, nil
+}
+
+// laptopColumns lists the columns of the laptops table set from the laptop, in the order of laptopValues
+const laptopColumns = `revision, deleted_at, brand, brand_folded, name_folded, price_usd, release_year,
+	cpu_brand, cpu_number_cores, cpu_min_ghz, ram_bits, ssd_bits, hdd_bits,
+	screen_size_inch, screen_width, screen_height, screen_pannel, screen_multitouch,
+	keyboard_layout, keyboard_backlit, weight_kg, data`
+
+func laptopValues(laptop *pb.Laptop) ([]interface{}, error) {
+	data, err := proto.Marshal(laptop)
+	if err != nil {
+		return nil, fmt.Errorf("cannot encode laptop: %w", err)
+	}
+
+	deletedAt := sql.NullInt64{}
+	if laptop.GetDeletedAt() != nil {
+		deletedAt = sql.NullInt64{Int64: laptop.GetDeletedAt().AsTime().UnixNano(), Valid: true}
+	}
+
+	screen := laptop.GetScreen()
+	return []interface{}{
+		int64(laptop.GetRevision()),
+		deletedAt,
+		laptop.GetBrand(),
+		folded(laptop.GetBrand()),
+		folded(laptop.GetName()),
+		laptop.GetPriceUsd(),
+		laptop.GetReleaseYear(),
+		laptop.GetCpu().GetBrand(),
+		laptop.GetCpu().GetNumberCores(),
+		laptop.GetCpu().GetMinGhz(),
+		sqlBits(toBit(laptop.GetRam())),
+		sqlBits(storageSize(laptop, pb.Storage_SSD)),
+		sqlBits(storageSize(laptop, pb.Storage_HDD)),
+		float64(screen.GetSizeInch()),
+		screen.GetResolution().GetWidth(),
+		screen.GetResolution().GetHeight(),
+		int32(screen.GetPannel()),
+		screen.GetMultitouch(),
+		int32(laptop.GetKeyboard().GetLayout()),
+		laptop.GetKeyboard().GetBacklit(),
+		weightKg(laptop),
+		data,
+	}, nil
+}
+
+// insertLaptop adds a row for the laptop, which is created after all stored laptops
+func insertLaptop(tx *sql.Tx, laptop *pb.Laptop) error {
+	sequence, err := nextCounter(tx, "sequence")
+	if err != nil {
+		return err
+	}
+
+	values, err := laptopValues(laptop)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO laptops (id, sequence, ` + laptopColumns + `) VALUES (?, ?, ` + placeholders(len(values)) + `)`
+	_, err = tx.Exec(query, append([]interface{}{laptop.GetId(), int64(sequence)}, values...)...)
+	if err != nil {
+		return fmt.Errorf("cannot insert laptop: %w", err)
+	}
+
+	err = insertGPUs(tx, laptop)
+	if err != nil {
+		return err
+	}
+
+	return insertStorages(tx, laptop)
+}
+
+// updateLaptop replaces the row of the laptop, keeping its creation sequence
+func updateLaptop(tx *sql.Tx, laptop *pb.Laptop) error {
+	values, err := laptopValues(laptop)
+	if err != nil {
+		return err
+	}
+
+	columns := strings.Split(laptopColumns, ",")
+	for i, column := range columns {
+		columns[i] = strings.TrimSpace(column) + " = ?"
+	}
+
+	query := `UPDATE laptops SET ` + strings.Join(columns, ", ") + ` WHERE id = ?`
+	_, err = tx.Exec(query, append(values, laptop.GetId())...)
+	if err != nil {
+		return fmt.Errorf("cannot update laptop: %w", err)
+	}
+
+	err = deleteGPUs(tx, laptop.GetId())
+	if err != nil {
+		return err
+	}
+
+	err = insertGPUs(tx, laptop)
+	if err != nil {
+		return err
+	}
+
+	err = deleteStorages(tx, laptop.GetId())
+	if err != nil {
+		return err
+	}
+
+	return insertStorages(tx, laptop)
+}
+
+func insertGPUs(tx *sql.Tx, laptop *pb.Laptop) error {
+	for _, gpu := range laptop.GetGpus() {
+		_, err := tx.Exec(`INSERT INTO laptop_gpus (laptop_id, brand, brand_folded, memory_bits) VALUES (?, ?, ?, ?)`,
+			laptop.GetId(), gpu.GetBrand(), folded(gpu.GetBrand()), sqlBits(toBit(gpu.GetMemory())))
+		if err != nil {
+			return fmt.Errorf("cannot insert GPU: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func deleteGPUs(tx *sql.Tx, laptopID string) error {
+	_, err := tx.Exec(`DELETE FROM laptop_gpus WHERE laptop_id = ?`, laptopID)
+	if err != nil {
+		return fmt.Errorf("cannot delete GPUs: %w", err)
+	}
+
+	return nil
+}
+
+func insertStorages(tx *sql.Tx, laptop *pb.Laptop) error {
+	for _, storage := range laptop.GetStorages() {
+		_, err := tx.Exec(`INSERT INTO laptop_storages (laptop_id, driver) VALUES (?, ?)`,
+			laptop.GetId(), int32(storage.GetDriver()))
+		if err != nil {
+			return fmt.Errorf("cannot insert storage: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func deleteStorages(tx *sql.Tx, laptopID string) error {
+	_, err := tx.Exec(`DELETE FROM laptop_storages WHERE laptop_id = ?`, laptopID)
+	if err != nil {
+		return fmt.Errorf("cannot delete storages: %w", err)
+	}
+
+	return nil
+}
+
+func (store *SQLLaptopStore) Find(ID string) (*pb.Laptop, error) {
+	laptop, err := selectLaptop(store.db, ID)
+	if err != nil || laptop.GetDeletedAt() != nil {
+		return nil, err
+	}
+
+	return laptop, nil
+}
+
+// selectLaptops runs a query selecting the sequence and data of laptops
+func selectLaptops(q querier, query string, args ...interface{}) ([]*pb.Laptop, []uint64, error) {
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot select laptops: %w", err)
+	}
+	defer rows.Close()
+
+	laptops := []*pb.Laptop{}
+	sequences := []uint64{}
+	for rows.Next() {
+		sequence := int64(0)
+		data := []byte{}
+		err = rows.Scan(&sequence, &data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot scan laptop: %w", err)
+		}
+
+		laptop, err := unmarshalLaptop(data)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		laptops = append(laptops, laptop)
+		sequences = append(sequences, uint64(sequence))
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot select laptops: %w", err)
+	}
+
+	return laptops, sequences, nil
+}
+
+// Search selects the laptops matching the filter in SQL. Expressions, text
+// relevance and ordering are then evaluated on the selected laptops.
+func (store *SQLLaptopStore) Search(query *SearchQuery, found func(*pb.Laptop) error) (string, error) {
+	return store.searchLaptops(query, func(cursor *searchResult) ([]*pb.Laptop, []uint64, error) {
+		conditions := []string{}
+		args := []interface{}{}
+		if query.appliesFilter() {
+			conditions, args = sqlFilter(query.Filter)
+		}
+		if !query.ShowDeleted {
+			conditions = append(conditions, "deleted_at IS NULL")
+		}
+		// laptops created before the cursor cannot be on the next page of the default order
+		if cursor != nil && query.ordering().GetField() == pb.OrderBy_DEFAULT {
+			conditions = append(conditions, "sequence > ?")
+			args = append(args, int64(cursor.sequence))
+		}
+
+		sqlQuery := `SELECT sequence, data FROM laptops` + sqlWhere(conditions) + ` ORDER BY sequence`
+		// without an expression or text, a page of the default order holds the
+		// next laptops, and one more tells whether there is a next page
+		if query.PageSize > 0 && query.Expression == nil && query.Text == "" && query.ordering().GetField() == pb.OrderBy_DEFAULT {
+			sqlQuery += ` LIMIT ?`
+			args = append(args, int64(query.PageSize)+1)
+		}
+
+		return selectLaptops(store.db, sqlQuery, args...)
+	}, found)
+}
+
+func sqlWhere(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(conditions, " AND ")
+}
+
+// Facets counts the laptops matching the filter in SQL, grouping them by the
+// value of each facet, so that the laptops do not have to be decoded
+func (store *SQLLaptopStore) Facets(filter *pb.Filter, widths HistogramWidths) (*pb.SearchFacetsResponse, error) {
+	conditions, args := sqlFilter(filter)
+	conditions = append(conditions, "deleted_at IS NULL")
+	where := sqlWhere(conditions)
+	matching := ` WHERE laptop_id IN (SELECT id FROM laptops` + where + `)`
+
+	// the counts are read in one transaction so that they agree with each other
+	tx, err := store.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("cannot begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	counter := newFacetCounter(widths)
+	err = tx.QueryRow(`SELECT COUNT(*) FROM laptops`+where, args...).Scan(&counter.total)
+	if err != nil {
+		return nil, fmt.Errorf("cannot count laptops: %w", err)
+	}
+
+	textFacets := []struct {
+		counts *map[string]uint32
+		query  string
+	}{
+		{&counter.brands, `SELECT brand, COUNT(*) FROM laptops` + where + ` GROUP BY brand`},
+		{&counter.cpuBrands, `SELECT cpu_brand, COUNT(*) FROM laptops` + where + ` GROUP BY cpu_brand`},
+		{&counter.gpuBrands, `SELECT brand, COUNT(DISTINCT laptop_id) FROM laptop_gpus` + matching + ` GROUP BY brand`},
+	}
+	for _, facet := range textFacets {
+		*facet.counts, err = selectTextCounts(tx, facet.query, args...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	enumFacets := []struct {
+		counts *map[string]uint32
+		query  string
+		name   func(value int32) string
+	}{
+		{&counter.keyboardLayouts, `SELECT keyboard_layout, COUNT(*) FROM laptops` + where + ` GROUP BY keyboard_layout`,
+			func(value int32) string { return pb.Keyboard_Layout(value).String() }},
+		{&counter.screenPannels, `SELECT screen_pannel, COUNT(*) FROM laptops` + where + ` GROUP BY screen_pannel`,
+			func(value int32) string { return pb.Screen_Pannel(value).String() }},
+		{&counter.storageDrivers, `SELECT driver, COUNT(DISTINCT laptop_id) FROM laptop_storages` + matching + ` GROUP BY driver`,
+			func(value int32) string { return pb.Storage_Driver(value).String() }},
+	}
+	for _, facet := range enumFacets {
+		counts, err := selectNumberCounts(tx, facet.query, args...)
+		if err != nil {
+			return nil, err
+		}
+
+		*facet.counts = make(map[string]uint32, len(counts))
+		for value, count := range counts {
+			(*facet.counts)[facet.name(int32(value))] += count
+		}
+	}
+
+	histograms := []struct {
+		counts *map[int64]uint32
+		value  string
+		width  float64
+	}{
+		{&counter.priceUsd, `price_usd`, counter.widths.PriceUsd},
+		{&counter.ramGb, fmt.Sprintf(`(ram_bits / %d.0)`, bitsPerGigabyte), counter.widths.RamGb},
+		{&counter.releaseYear, `release_year`, counter.widths.ReleaseYear},
+	}
+	for _, histogram := range histograms {
+		bucketArgs := append([]interface{}{histogram.width, histogram.width, histogram.width}, args...)
+		*histogram.counts, err = selectNumberCounts(tx, `SELECT `+sqlBucket(histogram.value)+`, COUNT(*) FROM laptops`+where+` GROUP BY 1`, bucketArgs...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return counter.response(), nil
+}
+
+// sqlBucket returns the SQL expression of the histogram bucket of the value,
+// rounding down its quotient by the width and clamping it like bucket. The
+// width is given by three ? placeholders.
+func sqlBucket(value string) string {
+	quotient := `(` + value + ` / ?)`
+	number := `CAST(` + quotient + ` AS INTEGER) - (` + quotient + ` < CAST(` + quotient + ` AS INTEGER))`
+	return fmt.Sprintf(`MIN(MAX(%s, %d), %d)`, number, -maxBucketNumber, maxBucketNumber)
+}
+
+// selectTextCounts runs a query selecting texts with their counts
+func selectTextCounts(q querier, query string, args ...interface{}) (map[string]uint32, error) {
+	counts := make(map[string]uint32)
+	err := selectCounts(q, query, args, func(rows *sql.Rows) error {
+		value := ""
+		count := uint32(0)
+		err := rows.Scan(&value, &count)
+		counts[value] += count
+		return err
+	})
+
+	return counts, err
+}
+
+// selectNumberCounts runs a query selecting integers with their counts
+func selectNumberCounts(q querier, query string, args ...interface{}) (map[int64]uint32, error) {
+	counts := make(map[int64]uint32)
+	err := selectCounts(q, query, args, func(rows *sql.Rows) error {
+		value := int64(0)
+		count := uint32(0)
+		err := rows.Scan(&value, &count)
+		counts[value] += count
+		return err
+	})
+
+	return counts, err
+}
+
+func selectCounts(q querier, query string, args []interface{}, scan func(rows *sql.Rows) error) error {
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return fmt.Errorf("cannot count laptops: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err = scan(rows)
+		if err != nil {
+			return fmt.Errorf("cannot scan count: %w", err)
+		}
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return fmt.Errorf("cannot count laptops: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// sqlMigrations create and upgrade the schema of the SQL stores. The
+// migration at index i brings the schema to version i+1, applied migrations
+// must never be changed.
+var sqlMigrations = [][]string{
+	{
+		`CREATE TABLE laptops (
+			id TEXT PRIMARY KEY,
+			sequence INTEGER NOT NULL UNIQUE,
+			revision INTEGER NOT NULL,
+			deleted_at INTEGER,
+			brand_folded TEXT NOT NULL,
+			name_folded TEXT NOT NULL,
+			price_usd REAL NOT NULL,
+			release_year INTEGER NOT NULL,
+			cpu_number_cores INTEGER NOT NULL,
+			cpu_min_ghz REAL NOT NULL,
+			ram_bits INTEGER NOT NULL,
+			ssd_bits INTEGER NOT NULL,
+			hdd_bits INTEGER NOT NULL,
+			screen_size_inch REAL NOT NULL,
+			screen_width INTEGER NOT NULL,
+			screen_height INTEGER NOT NULL,
+			screen_pannel INTEGER NOT NULL,
+			screen_multitouch BOOLEAN NOT NULL,
+			keyboard_layout INTEGER NOT NULL,
+			keyboard_backlit BOOLEAN NOT NULL,
+			weight_kg REAL NOT NULL,
+			data BLOB NOT NULL
+		)`,
+		`CREATE INDEX laptops_price_usd ON laptops (price_usd)`,
+		`CREATE INDEX laptops_release_year ON laptops (release_year)`,
+		`CREATE INDEX laptops_brand_folded ON laptops (brand_folded)`,
+		`CREATE INDEX laptops_deleted_at ON laptops (deleted_at)`,
+		`CREATE TABLE laptop_gpus (
+			laptop_id TEXT NOT NULL REFERENCES laptops (id),
+			brand_folded TEXT NOT NULL,
+			memory_bits INTEGER NOT NULL
+		)`,
+		`CREATE INDEX laptop_gpus_laptop_id ON laptop_gpus (laptop_id)`,
+		`CREATE TABLE laptop_counters (
+			name TEXT PRIMARY KEY,
+			value INTEGER NOT NULL
+		)`,
+		`INSERT INTO laptop_counters (name, value) VALUES ('revision', 0), ('sequence', 0)`,
+	},
+	{
+		`CREATE TABLE ratings (
+			laptop_id TEXT PRIMARY KEY,
+			count INTEGER NOT NULL,
+			sum REAL NOT NULL
+		)`,
+	},
+	{
+		`CREATE TABLE images (
+			id TEXT PRIMARY KEY,
+			laptop_id TEXT NOT NULL,
+			type TEXT NOT NULL,
+			path TEXT NOT NULL
+		)`,
+		`CREATE INDEX images_laptop_id ON images (laptop_id)`,
+	},
+	{
+		`ALTER TABLE laptops ADD COLUMN brand TEXT NOT NULL DEFAULT ''`,
+		`ALTER TABLE laptops ADD COLUMN cpu_brand TEXT NOT NULL DEFAULT ''`,
+		`ALTER TABLE laptop_gpus ADD COLUMN brand TEXT NOT NULL DEFAULT ''`,
+		`CREATE TABLE laptop_storages (
+			laptop_id TEXT NOT NULL REFERENCES laptops (id),
+			driver INTEGER NOT NULL
+		)`,
+		`CREATE INDEX laptop_storages_laptop_id ON laptop_storages (laptop_id)`,
+	},
+}
+
+// sqlBackfills fill in the columns added by the migration bringing the schema
+// to the version of their key, from the laptops stored before
+var sqlBackfills = map[int]func(tx *sql.Tx) error{
+	4: backfillFacetColumns,
+}
+
+// MigrateSQL brings the schema of the database up to date with the SQL stores
+// and returns its version. Each migration is applied in its own transaction.
+func MigrateSQL(db *sql.DB) (int, error) {
+	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER PRIMARY KEY)`)
+	if err != nil {
+		return 0, fmt.Errorf("cannot create migrations table: %w", err)
+	}
+
+	version := 0
+	err = db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&version)
+	if err != nil {
+		return 0, fmt.Errorf("cannot read schema version: %w", err)
+	}
+
+	for ; version < len(sqlMigrations); version++ {
+		err = migrateSQL(db, version+1, sqlMigrations[version])
+		if err != nil {
+			return version, fmt.Errorf("cannot migrate schema to version %d: %w", version+1, err)
+		}
+	}
+
+	return version, nil
+}
+
+func migrateSQL(db *sql.DB, version int, statements []string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, statement := range statements {
+		_, err = tx.Exec(statement)
+		if err != nil {
+			return err
+		}
+	}
+
+	backfill := sqlBackfills[version]
+	if backfill != nil {
+		err = backfill(tx)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(`INSERT INTO schema_migrations (version) VALUES (?)`, version)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// backfillFacetColumns rewrites the rows of the stored laptops, setting the
+// columns that facets are counted from
+func backfillFacetColumns(tx *sql.Tx) error {
+	laptops, _, err := selectLaptops(tx, `SELECT sequence, data FROM laptops ORDER BY sequence`)
+	if err != nil {
+		return err
+	}
+
+	for _, laptop := range laptops {
+		err = updateLaptop(tx, laptop)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
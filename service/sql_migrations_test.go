@@ -0,0 +1,56 @@
+package service
+
+import (
+	"database/sql"
+	"gRPC/pb"
+	"gRPC/sample"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestBackfillFacetColumns(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", "file:"+filepath.Join(t.TempDir(), "laptop.db")+"?_foreign_keys=on&_busy_timeout=5000")
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlStore, err := NewSQLLaptopStore(db)
+	require.NoError(t, err)
+	memoryStore := NewInMemoryLaptopStore()
+	for i := 0; i < 20; i++ {
+		laptop := sample.NewLaptop()
+		err = sqlStore.Save(proto.Clone(laptop).(*pb.Laptop))
+		require.NoError(t, err)
+		err = memoryStore.Save(laptop)
+		require.NoError(t, err)
+	}
+
+	// the laptops stored before the facet columns were added leave them empty
+	for _, statement := range []string{
+		`UPDATE laptops SET brand = '', cpu_brand = ''`,
+		`UPDATE laptop_gpus SET brand = ''`,
+		`DELETE FROM laptop_storages`,
+	} {
+		_, err = db.Exec(statement)
+		require.NoError(t, err)
+	}
+
+	tx, err := db.Begin()
+	require.NoError(t, err)
+	err = backfillFacetColumns(tx)
+	require.NoError(t, err)
+	err = tx.Commit()
+	require.NoError(t, err)
+
+	filter := &pb.Filter{MaxPriceUsd: 5000}
+	expected, err := memoryStore.Facets(filter, HistogramWidths{})
+	require.NoError(t, err)
+	actual, err := sqlStore.Facets(filter, HistogramWidths{})
+	require.NoError(t, err)
+	require.True(t, proto.Equal(expected, actual))
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// SQLRatingStore is a RatingStore backed by a relational database
+type SQLRatingStore struct {
+	db *sql.DB
+}
+
+// NewSQLRatingStore returns a store using the database, whose schema is migrated first
+func NewSQLRatingStore(db *sql.DB) (*SQLRatingStore, error) {
+	_, err := MigrateSQL(db)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SQLRatingStore{db: db}, nil
+}
+
+func (store *SQLRatingStore) Add(laptopID string, score float64) (*Rating, error) {
+	tx, err := store.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("cannot begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`INSERT INTO ratings (laptop_id, count, sum) VALUES (?, 1, ?)
+		ON CONFLICT (laptop_id) DO UPDATE SET count = count + 1, sum = sum + excluded.sum`, laptopID, score)
+	if err != nil {
+		return nil, fmt.Errorf("cannot add rating: %w", err)
+	}
+
+	rating, err := selectRating(tx, laptopID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("cannot commit transaction: %w", err)
+	}
+
+	return rating, nil
+}
+
+func (store *SQLRatingStore) Find(laptopID string) (*Rating, error) {
+	return selectRating(store.db, laptopID)
+}
+
+func (store *SQLRatingStore) Delete(laptopID string) error {
+	_, err := store.db.Exec(`DELETE FROM ratings WHERE laptop_id = ?`, laptopID)
+	if err != nil {
+		return fmt.Errorf("cannot delete rating: %w", err)
+	}
+
+	return nil
+}
+
+// selectRating returns the rating of the laptop, or nil if it was never rated
+func selectRating(q querier, laptopID string) (*Rating, error) {
+	rating := &Rating{}
+	err := q.QueryRow(`SELECT count, sum FROM ratings WHERE laptop_id = ?`, laptopID).Scan(&rating.Count, &rating.Sum)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot select rating: %w", err)
+	}
+
+	return rating, nil
+}
@@ -0,0 +1,317 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"gRPC/pb"
+	"gRPC/sample"
+	"gRPC/service"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func openTestSQLDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite3", "file:"+filepath.Join(t.TempDir(), "laptop.db")+"?_foreign_keys=on&_busy_timeout=5000")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestMigrateSQL(t *testing.T) {
+	t.Parallel()
+
+	db := openTestSQLDB(t)
+	version, err := service.MigrateSQL(db)
+	require.NoError(t, err)
+	require.Equal(t, 4, version)
+
+	// migrating again does nothing
+	version, err = service.MigrateSQL(db)
+	require.NoError(t, err)
+	require.Equal(t, 4, version)
+}
+
+func randomTestFilter(random *rand.Rand) *pb.Filter {
+	filter := &pb.Filter{
+		MaxPriceUsd: 1500 + random.Float64()*2000,
+		MinCpuCores: uint32(random.Intn(8)),
+		MinCpuGhz:   random.Float64() * 3,
+		MinRam:      &pb.Memory{Value: uint64(random.Intn(64)), Unit: pb.Memory_GIGABYTE},
+	}
+
+	switch random.Intn(6) {
+	case 0:
+		filter.MinPriceUsd = random.Float64() * 2000
+	case 1:
+		filter.Brands = []string{"dell", "APPLE"}[:1+random.Intn(2)]
+		filter.Names = []string{"xps", "Macbook Pro"}[:1+random.Intn(2)]
+	case 2:
+		filter.MinReleaseYear = uint32(2015 + random.Intn(5))
+		filter.MaxReleaseYear = filter.MinReleaseYear + uint32(random.Intn(3))
+	case 3:
+		filter.GpuBrand = []string{"", "nvidia", "AMD"}[random.Intn(3)]
+		filter.MinGpuMemory = &pb.Memory{Value: uint64(2 + random.Intn(5)), Unit: pb.Memory_GIGABYTE}
+	case 4:
+		filter.MinSsd = &pb.Memory{Value: uint64(random.Intn(1024)), Unit: pb.Memory_GIGABYTE}
+		filter.MinHdd = &pb.Memory{Value: uint64(random.Intn(6)), Unit: pb.Memory_TERABYTE}
+	case 5:
+		filter.MinScreenSizeInch = 13 + random.Float32()*2
+		filter.MaxScreenSizeInch = filter.MinScreenSizeInch + random.Float32()*3
+		filter.MinScreenResolution = &pb.Screen_Resolution{Width: uint32(random.Intn(4300)), Height: uint32(random.Intn(2500))}
+		filter.ScreenPannel = pb.Screen_Pannel(random.Intn(3))
+		filter.RequireMultitouch = random.Intn(2) == 0
+		filter.KeyboardLayout = pb.Keyboard_Layout(random.Intn(4))
+		filter.RequireBacklit = random.Intn(2) == 0
+		if random.Intn(2) == 0 {
+			filter.MaxWeight = &pb.Filter_MaxWeightKg{MaxWeightKg: 1 + random.Float64()*2}
+		} else {
+			filter.MaxWeight = &pb.Filter_MaxWeightLb{MaxWeightLb: 2 + random.Float64()*4}
+		}
+	}
+
+	return filter
+}
+
+func TestSQLLaptopStoreFilter(t *testing.T) {
+	t.Parallel()
+
+	seed := time.Now().UnixNano()
+	t.Logf("seed %d", seed)
+	random := rand.New(rand.NewSource(seed))
+
+	sqlStore, err := service.NewSQLLaptopStore(openTestSQLDB(t))
+	require.NoError(t, err)
+	memoryStore := service.NewInMemoryLaptopStore()
+
+	for i := 0; i < 200; i++ {
+		laptop := sample.NewLaptop()
+		err = sqlStore.Save(proto.Clone(laptop).(*pb.Laptop))
+		require.NoError(t, err)
+		err = memoryStore.Save(laptop)
+		require.NoError(t, err)
+	}
+
+	// the filter is translated to SQL, so it must select the same laptops as in memory
+	for i := 0; i < 300; i++ {
+		filter := randomTestFilter(random)
+		query := &service.SearchQuery{Filter: filter}
+		require.Equal(t, searchIDs(t, memoryStore, query), searchIDs(t, sqlStore, query), "filter: %v", filter)
+
+		expected, err := memoryStore.Facets(filter, service.HistogramWidths{})
+		require.NoError(t, err)
+		actual, err := sqlStore.Facets(filter, service.HistogramWidths{})
+		require.NoError(t, err)
+		require.True(t, proto.Equal(expected, actual), "filter: %v", filter)
+	}
+
+	// the default order is limited to a page in SQL
+	for _, orderBy := range []*pb.OrderBy{{Field: pb.OrderBy_PRICE_USD, Descending: true}, nil} {
+		query := &service.SearchQuery{
+			Filter:   &pb.Filter{MaxPriceUsd: 3000},
+			OrderBy:  orderBy,
+			PageSize: 7,
+		}
+		for {
+			expectedIDs := []string{}
+			expectedToken, err := memoryStore.Search(query, func(laptop *pb.Laptop) error {
+				expectedIDs = append(expectedIDs, laptop.Id)
+				return nil
+			})
+			require.NoError(t, err)
+
+			actualIDs := []string{}
+			actualToken, err := sqlStore.Search(query, func(laptop *pb.Laptop) error {
+				actualIDs = append(actualIDs, laptop.Id)
+				return nil
+			})
+			require.NoError(t, err)
+			require.Equal(t, expectedIDs, actualIDs)
+			require.Equal(t, expectedToken, actualToken)
+
+			if actualToken == "" {
+				break
+			}
+			query.PageToken = actualToken
+		}
+	}
+}
+
+func TestSQLLaptopStore(t *testing.T) {
+	t.Parallel()
+
+	store, err := service.NewSQLLaptopStore(openTestSQLDB(t))
+	require.NoError(t, err)
+
+	watch, err := store.WatchChanges(0)
+	require.NoError(t, err)
+	defer watch.Close()
+
+	laptop := sample.NewLaptop()
+	err = store.Save(laptop)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), laptop.Revision)
+
+	err = store.Save(laptop)
+	require.ErrorIs(t, err, service.ErrAlreadyExists)
+
+	other, err := store.Find(laptop.Id)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(laptop, other))
+
+	laptop.Name = "Updated"
+	err = store.Update(laptop, 1)
+	require.NoError(t, err)
+	err = store.Update(laptop, 1)
+	require.ErrorIs(t, err, service.ErrRevisionMismatch)
+
+	patched, err := store.Patch(laptop.Id, &pb.Laptop{PriceUsd: 999}, []string{"price_usd"}, laptop.Revision)
+	require.NoError(t, err)
+	require.Equal(t, "Updated", patched.Name)
+	require.Equal(t, 999.0, patched.PriceUsd)
+
+	second := sample.NewLaptop()
+	errs := store.SaveAll([]*pb.Laptop{second, laptop})
+	require.ErrorIs(t, errs[1], service.ErrAlreadyExists)
+	other, err = store.Find(second.Id)
+	require.NoError(t, err)
+	require.Nil(t, other)
+
+	errs = store.SaveAll([]*pb.Laptop{second})
+	require.Equal(t, []error{nil}, errs)
+
+	err = store.Delete(laptop.Id, 0)
+	require.NoError(t, err)
+	other, err = store.Find(laptop.Id)
+	require.NoError(t, err)
+	require.Nil(t, other)
+
+	filter := &pb.Filter{MaxPriceUsd: 5000}
+	require.Equal(t, []string{second.Id}, searchIDs(t, store, &service.SearchQuery{Filter: filter}))
+	require.Equal(t, []string{laptop.Id, second.Id}, searchIDs(t, store, &service.SearchQuery{Filter: filter, ShowDeleted: true}))
+
+	restored, err := store.Undelete(laptop.Id, 0)
+	require.NoError(t, err)
+	require.Nil(t, restored.DeletedAt)
+
+	created, err := store.Upsert(sample.NewLaptop())
+	require.NoError(t, err)
+	require.True(t, created)
+
+	err = store.Delete(laptop.Id, 0)
+	require.NoError(t, err)
+	purged, err := store.Purge(time.Now().Add(time.Second))
+	require.NoError(t, err)
+	require.Equal(t, []string{laptop.Id}, purged)
+
+	// the laptop is created again once purged
+	err = store.Save(laptop)
+	require.NoError(t, err)
+
+	types := []pb.LaptopChange_Type{}
+	for i := 0; i < 10; i++ {
+		change, err := watch.Next(context.Background())
+		require.NoError(t, err)
+		types = append(types, change.Type)
+	}
+	require.Equal(t, []pb.LaptopChange_Type{
+		pb.LaptopChange_CREATED,
+		pb.LaptopChange_UPDATED,
+		pb.LaptopChange_UPDATED,
+		pb.LaptopChange_CREATED,
+		pb.LaptopChange_DELETED,
+		pb.LaptopChange_UNDELETED,
+		pb.LaptopChange_CREATED,
+		pb.LaptopChange_DELETED,
+		pb.LaptopChange_PURGED,
+		pb.LaptopChange_CREATED,
+	}, types)
+}
+
+func TestSQLRatingAndImageStores(t *testing.T) {
+	t.Parallel()
+
+	db := openTestSQLDB(t)
+	ratingStore, err := service.NewSQLRatingStore(db)
+	require.NoError(t, err)
+
+	laptopID := sample.NewLaptop().Id
+	rating, err := ratingStore.Find(laptopID)
+	require.NoError(t, err)
+	require.Nil(t, rating)
+
+	for _, score := range []float64{3, 5} {
+		_, err = ratingStore.Add(laptopID, score)
+		require.NoError(t, err)
+	}
+	rating, err = ratingStore.Find(laptopID)
+	require.NoError(t, err)
+	require.Equal(t, &service.Rating{Count: 2, Sum: 8}, rating)
+
+	err = ratingStore.Delete(laptopID)
+	require.NoError(t, err)
+	rating, err = ratingStore.Find(laptopID)
+	require.NoError(t, err)
+	require.Nil(t, rating)
+
+	imageFolder := t.TempDir()
+	imageStore, err := service.NewSQLImageStore(db, imageFolder)
+	require.NoError(t, err)
+
+	imageID, err := imageStore.Save(laptopID, ".jpg", *bytes.NewBufferString("image"))
+	require.NoError(t, err)
+
+	info, err := imageStore.Find(imageID)
+	require.NoError(t, err)
+	require.Equal(t, laptopID, info.LaptopID)
+	require.Equal(t, ".jpg", info.Type)
+
+	deleted, err := imageStore.DeleteLaptopImages(laptopID)
+	require.NoError(t, err)
+	require.Equal(t, 1, deleted)
+
+	info, err = imageStore.Find(imageID)
+	require.NoError(t, err)
+	require.Nil(t, info)
+
+	images, err := os.ReadDir(imageFolder)
+	require.NoError(t, err)
+	require.Empty(t, images)
+}
+
+func TestSQLLaptopStoreFacetsLargeValues(t *testing.T) {
+	t.Parallel()
+
+	sqlStore, err := service.NewSQLLaptopStore(openTestSQLDB(t))
+	require.NoError(t, err)
+	memoryStore := service.NewInMemoryLaptopStore()
+
+	for _, price := range []float64{1000, 1e300} {
+		laptop := sample.NewLaptop()
+		laptop.PriceUsd = price
+		err = sqlStore.Save(proto.Clone(laptop).(*pb.Laptop))
+		require.NoError(t, err)
+		err = memoryStore.Save(laptop)
+		require.NoError(t, err)
+	}
+
+	// the bucket number of the largest price does not fit in an int64, so it is clamped
+	filter := &pb.Filter{MaxPriceUsd: math.MaxFloat64}
+	widths := service.HistogramWidths{PriceUsd: 1e-6}
+	expected, err := memoryStore.Facets(filter, widths)
+	require.NoError(t, err)
+	actual, err := sqlStore.Facets(filter, widths)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(expected, actual))
+
+	require.Len(t, actual.PriceUsd, 2)
+	require.Equal(t, float64(1<<53)*1e-6, actual.PriceUsd[1].Min)
+}
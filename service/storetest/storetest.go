@@ -0,0 +1,861 @@
+// Package storetest implements conformance tests that any LaptopStore,
+// ImageStore or RatingStore of the service package is expected to pass.
+// Run them from the tests of an implementation:
+//
+//	func TestMyLaptopStore(t *testing.T) {
+//		storetest.TestLaptopStore(t, func(t *testing.T) service.LaptopStore {
+//			return NewMyLaptopStore()
+//		})
+//	}
+package storetest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"gRPC/pb"
+	"gRPC/sample"
+	"gRPC/service"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// workers is how many goroutines the concurrency tests run
+const workers = 8
+
+// allFilter matches every sample laptop
+var allFilter = &pb.Filter{MaxPriceUsd: 1e9}
+
+// searchIDs returns the IDs of the laptops found by the query, in order
+func searchIDs(t *testing.T, store service.LaptopStore, query *service.SearchQuery) []string {
+	ids := []string{}
+	_, err := store.Search(query, func(laptop *pb.Laptop) error {
+		ids = append(ids, laptop.GetId())
+		return nil
+	})
+	require.NoError(t, err)
+	return ids
+}
+
+// TestLaptopStore runs the conformance tests of LaptopStore. newStore must
+// return a new empty store for every test.
+func TestLaptopStore(t *testing.T, newStore func(t *testing.T) service.LaptopStore) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, store service.LaptopStore)
+	}{
+		{"DuplicateIDs", testDuplicateIDs},
+		{"RoundTrip", testRoundTrip},
+		{"Isolation", testIsolation},
+		{"DeletedAtIgnored", testDeletedAtIgnored},
+		{"UpsertDeleted", testUpsertDeleted},
+		{"TextSearch", testTextSearch},
+		{"SearchCallbackError", testSearchCallbackError},
+		{"ConcurrentSaveFind", testConcurrentSaveFind},
+		{"ConcurrentReadWrite", testConcurrentReadWrite},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			tc.test(t, newStore(t))
+		})
+	}
+
+	t.Run("MemoryFilter", func(t *testing.T) {
+		testMemoryFilter(t, newStore)
+	})
+}
+
+func testDuplicateIDs(t *testing.T, store service.LaptopStore) {
+	laptop := sample.NewLaptop()
+	err := store.Save(laptop)
+	require.NoError(t, err)
+
+	err = store.Save(sample.NewLaptop())
+	require.NoError(t, err)
+
+	other := sample.NewLaptop()
+	other.Id = laptop.Id
+	err = store.Save(other)
+	require.ErrorIs(t, err, service.ErrAlreadyExists)
+
+	found, err := store.Find(laptop.Id)
+	require.NoError(t, err)
+	require.Equal(t, laptop.Name, found.Name)
+
+	// a batch is rejected as a whole, whether the ID is taken by the store or by the batch
+	first := sample.NewLaptop()
+	second := sample.NewLaptop()
+	second.Id = first.Id
+	errs := store.SaveAll([]*pb.Laptop{first, other, second})
+	require.Len(t, errs, 3)
+	require.NoError(t, errs[0])
+	require.ErrorIs(t, errs[1], service.ErrAlreadyExists)
+	require.ErrorIs(t, errs[2], service.ErrAlreadyExists)
+
+	found, err = store.Find(first.Id)
+	require.NoError(t, err)
+	require.Nil(t, found)
+	require.Len(t, searchIDs(t, store, &service.SearchQuery{Filter: allFilter}), 2)
+
+	// deleted laptops keep their IDs until they are purged
+	err = store.Delete(laptop.Id, 0)
+	require.NoError(t, err)
+	err = store.Save(other)
+	require.ErrorIs(t, err, service.ErrAlreadyExists)
+}
+
+// mutateLaptop changes fields at every level of the laptop, in place
+func mutateLaptop(laptop *pb.Laptop) {
+	laptop.Name = "Mutated"
+	laptop.PriceUsd++
+	laptop.Cpu.Name = "Mutated"
+	laptop.Ram.Value++
+	laptop.Screen.Resolution.Width++
+	laptop.Keyboard.Backlit = !laptop.Keyboard.Backlit
+	laptop.Gpus[0].Memory.Value++
+	laptop.Storages[0].Memory.Value++
+	laptop.UpdatedAt.Seconds++
+	switch weight := laptop.Weight.(type) {
+	case *pb.Laptop_WeightKg:
+		weight.WeightKg++
+	case *pb.Laptop_WeightLb:
+		weight.WeightLb++
+	}
+}
+
+// testRoundTrip checks that oneof, timestamp and repeated fields are kept by the store
+func testRoundTrip(t *testing.T, store service.LaptopStore) {
+	withWeightLb := sample.NewLaptop()
+	withWeightLb.Weight = &pb.Laptop_WeightLb{WeightLb: 4.5}
+	withoutWeight := sample.NewLaptop()
+	withoutWeight.Weight = nil
+
+	laptops := []*pb.Laptop{sample.NewLaptop(), withWeightLb, withoutWeight}
+	for _, laptop := range laptops {
+		laptop.UpdatedAt = timestamppb.New(time.Date(2021, 3, 4, 5, 6, 7, 891011121, time.UTC))
+		laptop.Gpus = append(laptop.Gpus, sample.NewGPU(), sample.NewGPU())
+		laptop.Storages = append(laptop.Storages, &pb.Storage{
+			Driver: pb.Storage_SSD,
+			Memory: &pb.Memory{Value: 256, Unit: pb.Memory_GIGABYTE},
+		})
+	}
+	// a missing timestamp must stay missing
+	withoutWeight.UpdatedAt = nil
+
+	for _, laptop := range laptops {
+		err := store.Save(laptop)
+		require.NoError(t, err)
+	}
+
+	found := map[string]*pb.Laptop{}
+	_, err := store.Search(&service.SearchQuery{Filter: allFilter}, func(laptop *pb.Laptop) error {
+		found[laptop.Id] = laptop
+		return nil
+	})
+	require.NoError(t, err)
+
+	for _, laptop := range laptops {
+		other, err := store.Find(laptop.Id)
+		require.NoError(t, err)
+		require.True(t, proto.Equal(laptop, other), "found %v", other)
+		require.True(t, proto.Equal(laptop, found[laptop.Id]), "searched %v", found[laptop.Id])
+	}
+
+	// the weight can change from one unit to the other
+	withWeightLb.Weight = &pb.Laptop_WeightKg{WeightKg: 2}
+	err = store.Update(withWeightLb, 0)
+	require.NoError(t, err)
+	other, err := store.Find(withWeightLb.Id)
+	require.NoError(t, err)
+	require.Equal(t, 2.0, other.GetWeightKg())
+	require.Zero(t, other.GetWeightLb())
+}
+
+// testDeletedAtIgnored checks that only Delete and Undelete change whether a
+// laptop is deleted, whatever DeletedAt the written laptops hold
+func testDeletedAtIgnored(t *testing.T, store service.LaptopStore) {
+	deletedAt := timestamppb.New(time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC))
+	requireNotDeleted := func(laptop *pb.Laptop) {
+		other, err := store.Find(laptop.Id)
+		require.NoError(t, err)
+		require.NotNil(t, other)
+		require.Nil(t, other.DeletedAt)
+	}
+
+	saved := sample.NewLaptop()
+	saved.DeletedAt = deletedAt
+	err := store.Save(saved)
+	require.NoError(t, err)
+	requireNotDeleted(saved)
+
+	batch := sample.NewLaptop()
+	batch.DeletedAt = deletedAt
+	errs := store.SaveAll([]*pb.Laptop{batch})
+	require.NoError(t, errs[0])
+	requireNotDeleted(batch)
+
+	err = store.Update(saved, 0)
+	require.NoError(t, err)
+	requireNotDeleted(saved)
+
+	upserted := sample.NewLaptop()
+	upserted.DeletedAt = deletedAt
+	_, err = store.Upsert(upserted)
+	require.NoError(t, err)
+	requireNotDeleted(upserted)
+	_, err = store.Upsert(upserted)
+	require.NoError(t, err)
+	requireNotDeleted(upserted)
+}
+
+// testUpsertDeleted checks that upserting a deleted laptop restores it and is
+// watched as such
+func testUpsertDeleted(t *testing.T, store service.LaptopStore) {
+	laptop := sample.NewLaptop()
+	err := store.Save(laptop)
+	require.NoError(t, err)
+	err = store.Delete(laptop.Id, 0)
+	require.NoError(t, err)
+
+	created, err := store.Upsert(laptop)
+	require.NoError(t, err)
+	require.True(t, created)
+
+	other, err := store.Find(laptop.Id)
+	require.NoError(t, err)
+	require.Equal(t, laptop.Revision, other.Revision)
+
+	watch, err := store.WatchChanges(0)
+	require.NoError(t, err)
+	defer watch.Close()
+
+	changeTypes := []pb.LaptopChange_Type{}
+	for i := 0; i < 3; i++ {
+		change, err := watch.Next(context.Background())
+		require.NoError(t, err)
+		changeTypes = append(changeTypes, change.Type)
+	}
+	require.Equal(t, []pb.LaptopChange_Type{pb.LaptopChange_CREATED, pb.LaptopChange_DELETED, pb.LaptopChange_UNDELETED}, changeTypes)
+}
+
+// testTextSearch checks that text searches follow the writes of the store
+func testTextSearch(t *testing.T, store service.LaptopStore) {
+	laptop := sample.NewLaptop()
+	laptop.Name = "Thinkpad"
+	other := sample.NewLaptop()
+	other.Name = "Inspiron"
+	errs := store.SaveAll([]*pb.Laptop{laptop, other})
+	require.Equal(t, []error{nil, nil}, errs)
+
+	query := &service.SearchQuery{Filter: allFilter, Text: "thinkpad", ShowDeleted: true}
+	require.Equal(t, []string{laptop.Id}, searchIDs(t, store, query))
+
+	_, err := store.Patch(other.Id, &pb.Laptop{Name: "Thinkpad Carbon"}, []string{"name"}, 0)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{laptop.Id, other.Id}, searchIDs(t, store, query))
+
+	laptop.Name = "Latitude"
+	err = store.Update(laptop, 0)
+	require.NoError(t, err)
+	require.Equal(t, []string{other.Id}, searchIDs(t, store, query))
+
+	// deleted laptops can still be searched until they are purged
+	err = store.Delete(other.Id, 0)
+	require.NoError(t, err)
+	require.Equal(t, []string{other.Id}, searchIDs(t, store, query))
+
+	_, err = store.Purge(time.Now().Add(time.Second))
+	require.NoError(t, err)
+	require.Empty(t, searchIDs(t, store, query))
+}
+
+// testIsolation checks that the store keeps its own copies of the laptops it is
+// given and that the laptops it returns, which are read-only, do not change
+// when the store is written
+func testIsolation(t *testing.T, store service.LaptopStore) {
+	laptop := sample.NewLaptop()
+	err := store.Save(laptop)
+	require.NoError(t, err)
+	saved := proto.Clone(laptop).(*pb.Laptop)
+
+	requireStored := func(expected *pb.Laptop) {
+		t.Helper()
+		found, err := store.Find(expected.Id)
+		require.NoError(t, err)
+		require.True(t, proto.Equal(expected, found), "stored laptop changed: %v", found)
+	}
+
+	// the store keeps its own copy of saved laptops
+	mutateLaptop(laptop)
+	requireStored(saved)
+
+	batch := sample.NewLaptop()
+	errs := store.SaveAll([]*pb.Laptop{batch})
+	require.NoError(t, errs[0])
+	savedBatch := proto.Clone(batch).(*pb.Laptop)
+	mutateLaptop(batch)
+	requireStored(savedBatch)
+
+	upserted := sample.NewLaptop()
+	_, err = store.Upsert(upserted)
+	require.NoError(t, err)
+	savedUpserted := proto.Clone(upserted).(*pb.Laptop)
+	mutateLaptop(upserted)
+	requireStored(savedUpserted)
+
+	// the laptops that were read do not change when the store is written
+	found, err := store.Find(laptop.Id)
+	require.NoError(t, err)
+	searched := []*pb.Laptop{}
+	_, err = store.Search(&service.SearchQuery{Filter: allFilter}, func(other *pb.Laptop) error {
+		if other.Id == laptop.Id {
+			searched = append(searched, other)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, searched, 1)
+
+	requireRead := func() {
+		t.Helper()
+		require.True(t, proto.Equal(saved, found), "found laptop changed: %v", found)
+		require.True(t, proto.Equal(saved, searched[0]), "searched laptop changed: %v", searched[0])
+	}
+
+	// the store keeps its own copy of updated laptops
+	updated := proto.Clone(saved).(*pb.Laptop)
+	mutateLaptop(updated)
+	err = store.Update(updated, 0)
+	require.NoError(t, err)
+	stored := proto.Clone(updated).(*pb.Laptop)
+	mutateLaptop(updated)
+	requireStored(stored)
+	requireRead()
+
+	patched, err := store.Patch(laptop.Id, &pb.Laptop{Name: "Patched"}, []string{"name"}, 0)
+	require.NoError(t, err)
+	stored.Name = "Patched"
+	stored.Revision = patched.Revision
+	requireStored(stored)
+	requireRead()
+
+	err = store.Delete(laptop.Id, 0)
+	require.NoError(t, err)
+	require.Equal(t, "Patched", patched.Name)
+	require.Nil(t, patched.DeletedAt)
+	requireRead()
+}
+
+func testSearchCallbackError(t *testing.T, store service.LaptopStore) {
+	for i := 0; i < 3; i++ {
+		err := store.Save(sample.NewLaptop())
+		require.NoError(t, err)
+	}
+
+	errStop := errors.New("stop")
+	queries := []*service.SearchQuery{
+		{Filter: allFilter},
+		{Filter: allFilter, OrderBy: &pb.OrderBy{Field: pb.OrderBy_PRICE_USD}},
+		{Filter: allFilter, PageSize: 2},
+	}
+	for _, query := range queries {
+		calls := 0
+		token, err := store.Search(query, func(*pb.Laptop) error {
+			calls++
+			return errStop
+		})
+		require.ErrorIs(t, err, errStop)
+		require.Empty(t, token)
+		require.Equal(t, 1, calls, "search went on after the callback failed")
+	}
+}
+
+func testConcurrentSaveFind(t *testing.T, store service.LaptopStore) {
+	const perWorker = 10
+
+	wg := sync.WaitGroup{}
+	laptops := make([][]*pb.Laptop, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(2)
+
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < perWorker; j++ {
+				laptop := sample.NewLaptop()
+				if !assert.NoError(t, store.Save(laptop)) {
+					return
+				}
+				laptops[i] = append(laptops[i], laptop)
+
+				found, err := store.Find(laptop.Id)
+				if assert.NoError(t, err) && assert.NotNil(t, found) {
+					assert.Equal(t, laptop.Revision, found.Revision)
+				}
+			}
+		}(i)
+
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perWorker; j++ {
+				ids := []string{}
+				_, err := store.Search(&service.SearchQuery{Filter: allFilter}, func(found *pb.Laptop) error {
+					ids = append(ids, found.Id)
+					return nil
+				})
+				if !assert.NoError(t, err) {
+					return
+				}
+
+				for _, id := range ids {
+					found, err := store.Find(id)
+					if assert.NoError(t, err) {
+						assert.NotNil(t, found, "laptop %s found by search is missing", id)
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.Len(t, searchIDs(t, store, &service.SearchQuery{Filter: allFilter}), workers*perWorker)
+	for _, saved := range laptops {
+		for _, laptop := range saved {
+			found, err := store.Find(laptop.Id)
+			require.NoError(t, err)
+			require.True(t, proto.Equal(laptop, found))
+		}
+	}
+}
+
+// testConcurrentReadWrite reads and marshals laptops while they are written.
+// Every write changes the name and the price together, so a laptop whose name
+// does not match its price was read while it was being written.
+func testConcurrentReadWrite(t *testing.T, store service.LaptopStore) {
+	const writes = 20
+
+	laptops := []*pb.Laptop{}
+	for i := 0; i < workers; i++ {
+		laptop := sample.NewLaptop()
+		laptop.Name = fmt.Sprint(laptop.PriceUsd)
+		err := store.Save(laptop)
+		require.NoError(t, err)
+		laptops = append(laptops, laptop)
+	}
+
+	checkLaptop := func(laptop *pb.Laptop) {
+		_, err := proto.Marshal(laptop)
+		assert.NoError(t, err)
+		assert.Equal(t, fmt.Sprint(laptop.PriceUsd), laptop.Name, "laptop %s was read while written", laptop.Id)
+	}
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < workers; i++ {
+		wg.Add(2)
+
+		go func(i int) {
+			defer wg.Done()
+			laptop := proto.Clone(laptops[i]).(*pb.Laptop)
+			for j := 0; j < writes; j++ {
+				laptop.PriceUsd++
+				laptop.Name = fmt.Sprint(laptop.PriceUsd)
+				if j%2 == 0 {
+					assert.NoError(t, store.Update(laptop, 0))
+					continue
+				}
+
+				patch := &pb.Laptop{PriceUsd: laptop.PriceUsd, Name: laptop.Name}
+				_, err := store.Patch(laptop.Id, patch, []string{"price_usd", "name"}, 0)
+				assert.NoError(t, err)
+			}
+		}(i)
+
+		go func() {
+			defer wg.Done()
+			for j := 0; j < writes; j++ {
+				for _, laptop := range laptops {
+					found, err := store.Find(laptop.Id)
+					if assert.NoError(t, err) && assert.NotNil(t, found) {
+						checkLaptop(found)
+					}
+				}
+
+				_, err := store.Search(&service.SearchQuery{Filter: allFilter}, func(found *pb.Laptop) error {
+					checkLaptop(found)
+					return nil
+				})
+				assert.NoError(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, laptop := range laptops {
+		found, err := store.Find(laptop.Id)
+		require.NoError(t, err)
+		require.Equal(t, laptop.PriceUsd+writes, found.PriceUsd)
+	}
+}
+
+func memory(value uint64, unit pb.Memory_Unit) *pb.Memory {
+	return &pb.Memory{Value: value, Unit: unit}
+}
+
+func storage(driver pb.Storage_Driver, memory *pb.Memory) *pb.Storage {
+	return &pb.Storage{Driver: driver, Memory: memory}
+}
+
+func gpu(memory *pb.Memory) *pb.GPU {
+	gpu := sample.NewGPU()
+	gpu.Memory = memory
+	return gpu
+}
+
+// testMemoryFilter checks that memory sizes in different units are compared by their number of bits
+func testMemoryFilter(t *testing.T, newStore func(t *testing.T) service.LaptopStore) {
+	tests := []struct {
+		name     string
+		laptop   func(laptop *pb.Laptop)
+		filter   func(filter *pb.Filter)
+		expected bool
+	}{
+		{
+			name:     "ram megabytes equal to gigabytes",
+			laptop:   func(laptop *pb.Laptop) { laptop.Ram = memory(8, pb.Memory_GIGABYTE) },
+			filter:   func(filter *pb.Filter) { filter.MinRam = memory(8192, pb.Memory_MEGABYTE) },
+			expected: true,
+		},
+		{
+			name:     "ram one megabyte short",
+			laptop:   func(laptop *pb.Laptop) { laptop.Ram = memory(8, pb.Memory_GIGABYTE) },
+			filter:   func(filter *pb.Filter) { filter.MinRam = memory(8193, pb.Memory_MEGABYTE) },
+			expected: false,
+		},
+		{
+			name:     "ram terabyte equal to gigabytes",
+			laptop:   func(laptop *pb.Laptop) { laptop.Ram = memory(1, pb.Memory_TERABYTE) },
+			filter:   func(filter *pb.Filter) { filter.MinRam = memory(1024, pb.Memory_GIGABYTE) },
+			expected: true,
+		},
+		{
+			name:     "ram bytes equal to kilobyte",
+			laptop:   func(laptop *pb.Laptop) { laptop.Ram = memory(1024, pb.Memory_BYTE) },
+			filter:   func(filter *pb.Filter) { filter.MinRam = memory(1, pb.Memory_KILOBYTE) },
+			expected: true,
+		},
+		{
+			name:     "ram bits equal to byte",
+			laptop:   func(laptop *pb.Laptop) { laptop.Ram = memory(8, pb.Memory_BIT) },
+			filter:   func(filter *pb.Filter) { filter.MinRam = memory(1, pb.Memory_BYTE) },
+			expected: true,
+		},
+		{
+			name:     "ram one bit short of byte",
+			laptop:   func(laptop *pb.Laptop) { laptop.Ram = memory(7, pb.Memory_BIT) },
+			filter:   func(filter *pb.Filter) { filter.MinRam = memory(1, pb.Memory_BYTE) },
+			expected: false,
+		},
+		{
+			name:     "min ram of unknown unit",
+			laptop:   func(laptop *pb.Laptop) { laptop.Ram = memory(1, pb.Memory_BIT) },
+			filter:   func(filter *pb.Filter) { filter.MinRam = memory(64, pb.Memory_UNKNOWN) },
+			expected: true,
+		},
+		{
+			name:     "ram of unknown unit",
+			laptop:   func(laptop *pb.Laptop) { laptop.Ram = memory(64, pb.Memory_UNKNOWN) },
+			filter:   func(filter *pb.Filter) { filter.MinRam = memory(1, pb.Memory_BIT) },
+			expected: false,
+		},
+		{
+			name: "ssd sizes are added up",
+			laptop: func(laptop *pb.Laptop) {
+				laptop.Storages = []*pb.Storage{
+					storage(pb.Storage_SSD, memory(512, pb.Memory_GIGABYTE)),
+					storage(pb.Storage_SSD, memory(524288, pb.Memory_MEGABYTE)),
+				}
+			},
+			filter:   func(filter *pb.Filter) { filter.MinSsd = memory(1, pb.Memory_TERABYTE) },
+			expected: true,
+		},
+		{
+			name: "hdd does not count as ssd",
+			laptop: func(laptop *pb.Laptop) {
+				laptop.Storages = []*pb.Storage{
+					storage(pb.Storage_SSD, memory(512, pb.Memory_GIGABYTE)),
+					storage(pb.Storage_HDD, memory(1, pb.Memory_TERABYTE)),
+				}
+			},
+			filter:   func(filter *pb.Filter) { filter.MinSsd = memory(1, pb.Memory_TERABYTE) },
+			expected: false,
+		},
+		{
+			name: "hdd terabytes equal to gigabytes",
+			laptop: func(laptop *pb.Laptop) {
+				laptop.Storages = []*pb.Storage{storage(pb.Storage_HDD, memory(2, pb.Memory_TERABYTE))}
+			},
+			filter:   func(filter *pb.Filter) { filter.MinHdd = memory(2048, pb.Memory_GIGABYTE) },
+			expected: true,
+		},
+		{
+			name:     "gpu megabytes equal to gigabytes",
+			laptop:   func(laptop *pb.Laptop) { laptop.Gpus = []*pb.GPU{gpu(memory(4096, pb.Memory_MEGABYTE))} },
+			filter:   func(filter *pb.Filter) { filter.MinGpuMemory = memory(4, pb.Memory_GIGABYTE) },
+			expected: true,
+		},
+		{
+			name: "gpu memories are not added up",
+			laptop: func(laptop *pb.Laptop) {
+				laptop.Gpus = []*pb.GPU{gpu(memory(2, pb.Memory_GIGABYTE)), gpu(memory(2048, pb.Memory_MEGABYTE))}
+			},
+			filter:   func(filter *pb.Filter) { filter.MinGpuMemory = memory(4, pb.Memory_GIGABYTE) },
+			expected: false,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			store := newStore(t)
+
+			laptop := sample.NewLaptop()
+			tc.laptop(laptop)
+			err := store.Save(laptop)
+			require.NoError(t, err)
+
+			filter := proto.Clone(allFilter).(*pb.Filter)
+			tc.filter(filter)
+
+			expected := []string{}
+			if tc.expected {
+				expected = append(expected, laptop.Id)
+			}
+			require.Equal(t, expected, searchIDs(t, store, &service.SearchQuery{Filter: filter}))
+		})
+	}
+}
+
+// TestImageStore runs the conformance tests of ImageStore. newStore must
+// return a new empty store writing its images to the folder, in files named
+// after the laptop and image IDs.
+func TestImageStore(t *testing.T, newStore func(t *testing.T, imageFolder string) service.ImageStore) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, store service.ImageStore, imageFolder string)
+	}{
+		{"Save", testImageSave},
+		{"DeleteLaptopImages", testDeleteLaptopImages},
+		{"ConcurrentSave", testConcurrentImageSave},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			imageFolder := t.TempDir()
+			tc.test(t, newStore(t, imageFolder), imageFolder)
+		})
+	}
+}
+
+// imageFiles returns the names of the files in the folder
+func imageFiles(t *testing.T, imageFolder string) []string {
+	entries, err := os.ReadDir(imageFolder)
+	require.NoError(t, err)
+
+	names := []string{}
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return names
+}
+
+func testImageSave(t *testing.T, store service.ImageStore, imageFolder string) {
+	laptopID := sample.NewLaptop().Id
+	first, err := store.Save(laptopID, ".jpg", *bytes.NewBufferString("first"))
+	require.NoError(t, err)
+	second, err := store.Save(laptopID, ".png", *bytes.NewBufferString("second"))
+	require.NoError(t, err)
+	require.NotEqual(t, first, second)
+
+	data, err := os.ReadFile(filepath.Join(imageFolder, laptopID+"_"+first+".jpg"))
+	require.NoError(t, err)
+	require.Equal(t, "first", string(data))
+
+	data, err = os.ReadFile(filepath.Join(imageFolder, laptopID+"_"+second+".png"))
+	require.NoError(t, err)
+	require.Equal(t, "second", string(data))
+}
+
+func testDeleteLaptopImages(t *testing.T, store service.ImageStore, imageFolder string) {
+	laptopID := sample.NewLaptop().Id
+	otherID := sample.NewLaptop().Id
+	for i := 0; i < 2; i++ {
+		_, err := store.Save(laptopID, ".jpg", *bytes.NewBufferString("image"))
+		require.NoError(t, err)
+	}
+	kept, err := store.Save(otherID, ".jpg", *bytes.NewBufferString("image"))
+	require.NoError(t, err)
+
+	deleted, err := store.DeleteLaptopImages(laptopID)
+	require.NoError(t, err)
+	require.Equal(t, 2, deleted)
+	require.Equal(t, []string{otherID + "_" + kept + ".jpg"}, imageFiles(t, imageFolder))
+
+	deleted, err = store.DeleteLaptopImages(laptopID)
+	require.NoError(t, err)
+	require.Equal(t, 0, deleted)
+}
+
+func testConcurrentImageSave(t *testing.T, store service.ImageStore, imageFolder string) {
+	const perWorker = 5
+
+	laptopID := sample.NewLaptop().Id
+	wg := sync.WaitGroup{}
+	ids := make([][]string, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < perWorker; j++ {
+				imageID, err := store.Save(laptopID, ".jpg", *bytes.NewBufferString(fmt.Sprintf("image %d %d", i, j)))
+				if !assert.NoError(t, err) {
+					return
+				}
+				ids[i] = append(ids[i], imageID)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	unique := map[string]bool{}
+	for _, workerIDs := range ids {
+		for _, imageID := range workerIDs {
+			unique[imageID] = true
+		}
+	}
+	require.Len(t, unique, workers*perWorker)
+	require.Len(t, imageFiles(t, imageFolder), workers*perWorker)
+
+	deleted, err := store.DeleteLaptopImages(laptopID)
+	require.NoError(t, err)
+	require.Equal(t, workers*perWorker, deleted)
+}
+
+// TestRatingStore runs the conformance tests of RatingStore. newStore must
+// return a new empty store for every test.
+func TestRatingStore(t *testing.T, newStore func(t *testing.T) service.RatingStore) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, store service.RatingStore)
+	}{
+		{"Add", testRatingAdd},
+		{"CopyIsolation", testRatingCopyIsolation},
+		{"Delete", testRatingDelete},
+		{"ConcurrentAdd", testConcurrentRatingAdd},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			tc.test(t, newStore(t))
+		})
+	}
+}
+
+func testRatingAdd(t *testing.T, store service.RatingStore) {
+	laptopID := sample.NewLaptop().Id
+	rating, err := store.Find(laptopID)
+	require.NoError(t, err)
+	require.Nil(t, rating)
+
+	rating, err = store.Add(laptopID, 5)
+	require.NoError(t, err)
+	require.Equal(t, &service.Rating{Count: 1, Sum: 5}, rating)
+
+	rating, err = store.Add(laptopID, 3)
+	require.NoError(t, err)
+	require.Equal(t, &service.Rating{Count: 2, Sum: 8}, rating)
+
+	rating, err = store.Find(laptopID)
+	require.NoError(t, err)
+	require.Equal(t, &service.Rating{Count: 2, Sum: 8}, rating)
+}
+
+func testRatingCopyIsolation(t *testing.T, store service.RatingStore) {
+	laptopID := sample.NewLaptop().Id
+	rating, err := store.Add(laptopID, 5)
+	require.NoError(t, err)
+	rating.Count = 100
+
+	rating, err = store.Find(laptopID)
+	require.NoError(t, err)
+	rating.Sum = 100
+
+	rating, err = store.Find(laptopID)
+	require.NoError(t, err)
+	require.Equal(t, &service.Rating{Count: 1, Sum: 5}, rating)
+}
+
+func testRatingDelete(t *testing.T, store service.RatingStore) {
+	laptopID := sample.NewLaptop().Id
+	otherID := sample.NewLaptop().Id
+	for _, id := range []string{laptopID, otherID} {
+		_, err := store.Add(id, 4)
+		require.NoError(t, err)
+	}
+
+	err := store.Delete(laptopID)
+	require.NoError(t, err)
+	rating, err := store.Find(laptopID)
+	require.NoError(t, err)
+	require.Nil(t, rating)
+
+	rating, err = store.Find(otherID)
+	require.NoError(t, err)
+	require.Equal(t, &service.Rating{Count: 1, Sum: 4}, rating)
+
+	// deleting a missing rating is not an error
+	err = store.Delete(laptopID)
+	require.NoError(t, err)
+
+	rating, err = store.Add(laptopID, 2)
+	require.NoError(t, err)
+	require.Equal(t, &service.Rating{Count: 1, Sum: 2}, rating)
+}
+
+func testConcurrentRatingAdd(t *testing.T, store service.RatingStore) {
+	const perWorker = 25
+
+	laptopID := sample.NewLaptop().Id
+	wg := sync.WaitGroup{}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perWorker; j++ {
+				rating, err := store.Add(laptopID, 2)
+				if assert.NoError(t, err) {
+					assert.Equal(t, 2*float64(rating.Count), rating.Sum)
+				}
+
+				_, err = store.Find(laptopID)
+				assert.NoError(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	rating, err := store.Find(laptopID)
+	require.NoError(t, err)
+	require.Equal(t, &service.Rating{Count: workers * perWorker, Sum: 2 * workers * perWorker}, rating)
+}
@@ -0,0 +1,111 @@
+package storetest_test
+
+import (
+	"database/sql"
+	"gRPC/service"
+	"gRPC/service/storetest"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func openSQLDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite3", "file:"+filepath.Join(t.TempDir(), "laptop.db")+"?_foreign_keys=on&_busy_timeout=5000")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestLaptopStores(t *testing.T) {
+	t.Parallel()
+
+	stores := map[string]func(t *testing.T) service.LaptopStore{
+		"InMemory": func(t *testing.T) service.LaptopStore {
+			return service.NewInMemoryLaptopStore()
+		},
+		"File": func(t *testing.T) service.LaptopStore {
+			store, err := service.NewFileLaptopStore(t.TempDir())
+			require.NoError(t, err)
+			return store
+		},
+		"WAL": func(t *testing.T) service.LaptopStore {
+			store, err := service.NewWALLaptopStore(t.TempDir(), 10)
+			require.NoError(t, err)
+			t.Cleanup(func() { store.Close() })
+			return store
+		},
+		"SQL": func(t *testing.T) service.LaptopStore {
+			store, err := service.NewSQLLaptopStore(openSQLDB(t))
+			require.NoError(t, err)
+			return store
+		},
+		"Bolt": func(t *testing.T) service.LaptopStore {
+			store, err := service.NewBoltLaptopStore(filepath.Join(t.TempDir(), "laptop.bolt"))
+			require.NoError(t, err)
+			t.Cleanup(func() { store.Close() })
+			return store
+		},
+	}
+
+	for name, newStore := range stores {
+		newStore := newStore
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			storetest.TestLaptopStore(t, newStore)
+		})
+	}
+}
+
+func TestImageStores(t *testing.T) {
+	t.Parallel()
+
+	stores := map[string]func(t *testing.T, imageFolder string) service.ImageStore{
+		"Disk": func(t *testing.T, imageFolder string) service.ImageStore {
+			return service.NewDiscImageStore(imageFolder)
+		},
+		"SQL": func(t *testing.T, imageFolder string) service.ImageStore {
+			store, err := service.NewSQLImageStore(openSQLDB(t), imageFolder)
+			require.NoError(t, err)
+			return store
+		},
+	}
+
+	for name, newStore := range stores {
+		newStore := newStore
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			storetest.TestImageStore(t, newStore)
+		})
+	}
+}
+
+func TestRatingStores(t *testing.T) {
+	t.Parallel()
+
+	stores := map[string]func(t *testing.T) service.RatingStore{
+		"InMemory": func(t *testing.T) service.RatingStore {
+			return service.NewInMemoryRatingStore()
+		},
+		"WAL": func(t *testing.T) service.RatingStore {
+			store, err := service.NewWALRatingStore(t.TempDir(), 10)
+			require.NoError(t, err)
+			t.Cleanup(func() { store.Close() })
+			return store
+		},
+		"SQL": func(t *testing.T) service.RatingStore {
+			store, err := service.NewSQLRatingStore(openSQLDB(t))
+			require.NoError(t, err)
+			return store
+		},
+	}
+
+	for name, newStore := range stores {
+		newStore := newStore
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			storetest.TestRatingStore(t, newStore)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"gRPC/pb"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	// scores of a query term matching a whole token or only the start of a token
+	tokenMatchScore  = 2
+	prefixMatchScore = 1
+)
+
+// textIndex is an inverted index over the brand, name, CPU name and GPU names of laptops.
+// It is not safe for concurrent use, the store guards it with its own mutex.
+type textIndex struct {
+	// postings maps each token to the IDs of the laptops containing it
+	postings map[string]map[string]bool
+	// documents keeps the tokens of each laptop so that they can be removed
+	documents map[string][]string
+	// tokens keeps all indexed tokens sorted, for prefix lookups
+	tokens []string
+}
+
+func newTextIndex() *textIndex {
+	return &textIndex{
+		postings:  make(map[string]map[string]bool),
+		documents: make(map[string][]string),
+	}
+}
+
+// tokenize splits text into lower case words of letters and digits
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func laptopTokens(laptop *pb.Laptop) []string {
+	tokens := tokenize(laptop.GetBrand())
+	tokens = append(tokens, tokenize(laptop.GetName())...)
+	tokens = append(tokens, tokenize(laptop.GetCpu().GetName())...)
+	for _, gpu := range laptop.GetGpus() {
+		tokens = append(tokens, tokenize(gpu.GetName())...)
+	}
+
+	return tokens
+}
+
+// add indexes the laptop, replacing what was indexed for the same ID before
+func (index *textIndex) add(laptop *pb.Laptop) {
+	index.remove(laptop.GetId())
+
+	tokens := laptopTokens(laptop)
+	index.documents[laptop.GetId()] = tokens
+
+	for _, token := range tokens {
+		ids := index.postings[token]
+		if ids == nil {
+			ids = make(map[string]bool)
+			index.postings[token] = ids
+
+			i := sort.SearchStrings(index.tokens, token)
+			index.tokens = append(index.tokens, "")
+			copy(index.tokens[i+1:], index.tokens[i:])
+			index.tokens[i] = token
+		}
+		ids[laptop.GetId()] = true
+	}
+}
+
+func (index *textIndex) remove(laptopID string) {
+	for _, token := range index.documents[laptopID] {
+		ids := index.postings[token]
+		delete(ids, laptopID)
+
+		if len(ids) == 0 {
+			delete(index.postings, token)
+
+			i := sort.SearchStrings(index.tokens, token)
+			if i < len(index.tokens) && index.tokens[i] == token {
+				index.tokens = append(index.tokens[:i], index.tokens[i+1:]...)
+			}
+		}
+	}
+
+	delete(index.documents, laptopID)
+}
+
+// search returns the relevance score of every laptop matching at least one word of the text.
+// Each word adds tokenMatchScore if a token of the laptop equals it, or
+// prefixMatchScore if a token only starts with it.
+func (index *textIndex) search(text string) map[string]float64 {
+	scores := make(map[string]float64)
+
+	for _, term := range tokenize(text) {
+		termScores := make(map[string]float64)
+
+		i := sort.SearchStrings(index.tokens, term)
+		for ; i < len(index.tokens) && strings.HasPrefix(index.tokens[i], term); i++ {
+			score := float64(prefixMatchScore)
+			if index.tokens[i] == term {
+				score = tokenMatchScore
+			}
+
+			for id := range index.postings[index.tokens[i]] {
+				if termScores[id] < score {
+					termScores[id] = score
+				}
+			}
+		}
+
+		for id, score := range termScores {
+			scores[id] += score
+		}
+	}
+
+	return scores
+}
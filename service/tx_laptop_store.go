@@ -0,0 +1,437 @@
+package service
+
+import (
+	"fmt"
+	"gRPC/pb"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// laptopTx is a transaction of a store keeping its laptops outside of memory
+type laptopTx interface {
+	// get returns the stored laptop, deleted or not, or nil if there is none
+	get(ID string) (*pb.Laptop, error)
+	// nextRevision increments the store-wide revision and returns it
+	nextRevision() (uint64, error)
+	// insert adds the laptop, which is created after all stored laptops
+	insert(laptop *pb.Laptop) error
+	// update replaces the old laptop by the laptop with the same ID, keeping its creation order
+	update(old *pb.Laptop, laptop *pb.Laptop) error
+	// remove removes the laptop permanently
+	remove(laptop *pb.Laptop) error
+	// deletedBefore returns the laptops deleted before the given time, in the order they were created
+	deletedBefore(deletedBefore time.Time) ([]*pb.Laptop, error)
+	// all returns every stored laptop, deleted or not
+	all() ([]*pb.Laptop, error)
+}
+
+// txLaptopStore implements the writes and watches of LaptopStore on top of
+// the transactions of a store that keeps its laptops outside of memory.
+// Changes are only watched, and the text index only kept up to date, for the
+// writes made through the store.
+type txLaptopStore struct {
+	// mutex serializes the writes so that changes are recorded in the order they are committed
+	mutex   sync.Mutex
+	changes *changeLog
+
+	// textIndex indexes the stored laptops, it is built when the store is
+	// opened and then updated with the changes of every write
+	textMutex sync.RWMutex
+	textIndex *textIndex
+
+	// transact runs the function in a transaction, which is committed if the function succeeds
+	transact func(func(tx laptopTx) error) error
+}
+
+func newTxLaptopStore(transact func(func(tx laptopTx) error) error) (*txLaptopStore, error) {
+	store := &txLaptopStore{
+		changes:   newChangeLog(defaultChangeLogSize),
+		textIndex: newTextIndex(),
+		transact:  transact,
+	}
+
+	err := transact(func(tx laptopTx) error {
+		laptops, err := tx.all()
+		if err != nil {
+			return err
+		}
+
+		for _, laptop := range laptops {
+			store.textIndex.add(laptop)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot build text index: %w", err)
+	}
+
+	return store, nil
+}
+
+// write runs the function in a transaction with the mutex locked. The changes
+// the function returns are recorded once the transaction is committed.
+func (store *txLaptopStore) write(write func(tx laptopTx) ([]*pb.LaptopChange, error)) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	var changes []*pb.LaptopChange
+	err := store.transact(func(tx laptopTx) error {
+		var err error
+		changes, err = write(tx)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	store.textMutex.Lock()
+	for _, change := range changes {
+		// deleted laptops stay indexed so that searches can show them
+		switch {
+		case change.After != nil:
+			store.textIndex.add(change.After)
+		case change.Type == pb.LaptopChange_PURGED:
+			store.textIndex.remove(change.Before.GetId())
+		}
+	}
+	store.textMutex.Unlock()
+
+	for _, change := range changes {
+		store.changes.append(change.Type, change.Before, change.After)
+	}
+	return nil
+}
+
+func laptopChange(changeType pb.LaptopChange_Type, before *pb.Laptop, after *pb.Laptop) *pb.LaptopChange {
+	return &pb.LaptopChange{Type: changeType, Before: before, After: after}
+}
+
+// createLaptop stores a copy of the laptop, whose ID must not be taken, and sets its revision
+func createLaptop(tx laptopTx, laptop *pb.Laptop) (*pb.Laptop, error) {
+	revision, err := tx.nextRevision()
+	if err != nil {
+		return nil, err
+	}
+
+	other := writtenCopy(laptop)
+	other.Revision = revision
+	err = tx.insert(other)
+	if err != nil {
+		return nil, err
+	}
+
+	laptop.Revision = revision
+	return other, nil
+}
+
+// replaceLaptop stores other in place of old and sets its revision
+func replaceLaptop(tx laptopTx, old *pb.Laptop, other *pb.Laptop) error {
+	revision, err := tx.nextRevision()
+	if err != nil {
+		return err
+	}
+
+	other.Revision = revision
+	return tx.update(old, other)
+}
+
+func (store *txLaptopStore) Save(laptop *pb.Laptop) error {
+	return store.write(func(tx laptopTx) ([]*pb.LaptopChange, error) {
+		old, err := tx.get(laptop.GetId())
+		if err != nil {
+			return nil, err
+		}
+		if old != nil {
+			return nil, ErrAlreadyExists
+		}
+
+		other, err := createLaptop(tx, laptop)
+		if err != nil {
+			return nil, err
+		}
+
+		return []*pb.LaptopChange{laptopChange(pb.LaptopChange_CREATED, nil, other)}, nil
+	})
+}
+
+func (store *txLaptopStore) SaveAll(laptops []*pb.Laptop) []error {
+	errs := make([]error, len(laptops))
+	failed := false
+	err := store.write(func(tx laptopTx) ([]*pb.LaptopChange, error) {
+		ids := make(map[string]bool)
+		for i, laptop := range laptops {
+			old, err := tx.get(laptop.GetId())
+			if err != nil {
+				return nil, err
+			}
+			if old != nil || ids[laptop.GetId()] {
+				errs[i] = ErrAlreadyExists
+				failed = true
+			}
+			ids[laptop.GetId()] = true
+		}
+
+		if failed {
+			return nil, nil
+		}
+
+		changes := []*pb.LaptopChange{}
+		for _, laptop := range laptops {
+			other, err := createLaptop(tx, laptop)
+			if err != nil {
+				return nil, err
+			}
+			changes = append(changes, laptopChange(pb.LaptopChange_CREATED, nil, other))
+		}
+
+		return changes, nil
+	})
+
+	if err != nil && !failed {
+		for i := range errs {
+			errs[i] = err
+		}
+	}
+	return errs
+}
+
+func (store *txLaptopStore) Update(laptop *pb.Laptop, expectedRevision uint64) error {
+	return store.write(func(tx laptopTx) ([]*pb.LaptopChange, error) {
+		old, err := tx.get(laptop.GetId())
+		if err != nil {
+			return nil, err
+		}
+
+		err = checkRevision(old, expectedRevision)
+		if err != nil {
+			return nil, err
+		}
+
+		other := writtenCopy(laptop)
+		err = replaceLaptop(tx, old, other)
+		if err != nil {
+			return nil, err
+		}
+
+		laptop.Revision = other.Revision
+		return []*pb.LaptopChange{laptopChange(pb.LaptopChange_UPDATED, old, other)}, nil
+	})
+}
+
+func (store *txLaptopStore) Upsert(laptop *pb.Laptop) (bool, error) {
+	created := false
+	err := store.write(func(tx laptopTx) ([]*pb.LaptopChange, error) {
+		old, err := tx.get(laptop.GetId())
+		if err != nil {
+			return nil, err
+		}
+
+		if old == nil {
+			created = true
+			other, err := createLaptop(tx, laptop)
+			if err != nil {
+				return nil, err
+			}
+			return []*pb.LaptopChange{laptopChange(pb.LaptopChange_CREATED, nil, other)}, nil
+		}
+
+		// replacing a deleted laptop restores it, which counts as creating it
+		created = old.GetDeletedAt() != nil
+		other := writtenCopy(laptop)
+		err = replaceLaptop(tx, old, other)
+		if err != nil {
+			return nil, err
+		}
+
+		laptop.Revision = other.Revision
+		changeType := pb.LaptopChange_UPDATED
+		if created {
+			changeType = pb.LaptopChange_UNDELETED
+		}
+		return []*pb.LaptopChange{laptopChange(changeType, old, other)}, nil
+	})
+
+	return created, err
+}
+
+func (store *txLaptopStore) Patch(ID string, patch *pb.Laptop, paths []string, expectedRevision uint64) (*pb.Laptop, error) {
+	var patched *pb.Laptop
+	err := store.write(func(tx laptopTx) ([]*pb.LaptopChange, error) {
+		old, err := tx.get(ID)
+		if err != nil {
+			return nil, err
+		}
+
+		err = checkRevision(old, expectedRevision)
+		if err != nil {
+			return nil, err
+		}
+
+		other := proto.Clone(old).(*pb.Laptop)
+		err = applyFieldMask(other, patch, paths)
+		if err != nil {
+			return nil, err
+		}
+
+		err = replaceLaptop(tx, old, other)
+		if err != nil {
+			return nil, err
+		}
+
+		patched = proto.Clone(other).(*pb.Laptop)
+		return []*pb.LaptopChange{laptopChange(pb.LaptopChange_UPDATED, old, other)}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return patched, nil
+}
+
+func (store *txLaptopStore) Delete(ID string, expectedRevision uint64) error {
+	return store.write(func(tx laptopTx) ([]*pb.LaptopChange, error) {
+		old, err := tx.get(ID)
+		if err != nil {
+			return nil, err
+		}
+
+		err = checkRevision(old, expectedRevision)
+		if err != nil {
+			return nil, err
+		}
+
+		other := proto.Clone(old).(*pb.Laptop)
+		other.DeletedAt = timestamppb.Now()
+		err = replaceLaptop(tx, old, other)
+		if err != nil {
+			return nil, err
+		}
+
+		return []*pb.LaptopChange{laptopChange(pb.LaptopChange_DELETED, old, nil)}, nil
+	})
+}
+
+func (store *txLaptopStore) Undelete(ID string, expectedRevision uint64) (*pb.Laptop, error) {
+	var restored *pb.Laptop
+	err := store.write(func(tx laptopTx) ([]*pb.LaptopChange, error) {
+		old, err := tx.get(ID)
+		if err != nil {
+			return nil, err
+		}
+		if old == nil {
+			return nil, ErrNotFound
+		}
+		if old.GetDeletedAt() == nil {
+			return nil, ErrNotDeleted
+		}
+		if expectedRevision != 0 && old.GetRevision() != expectedRevision {
+			return nil, fmt.Errorf("%w: expected %d, got %d", ErrRevisionMismatch, expectedRevision, old.GetRevision())
+		}
+
+		other := proto.Clone(old).(*pb.Laptop)
+		other.DeletedAt = nil
+		err = replaceLaptop(tx, old, other)
+		if err != nil {
+			return nil, err
+		}
+
+		restored = proto.Clone(other).(*pb.Laptop)
+		return []*pb.LaptopChange{laptopChange(pb.LaptopChange_UNDELETED, old, other)}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return restored, nil
+}
+
+func (store *txLaptopStore) Purge(deletedBefore time.Time) ([]string, error) {
+	purged := []string{}
+	err := store.write(func(tx laptopTx) ([]*pb.LaptopChange, error) {
+		laptops, err := tx.deletedBefore(deletedBefore)
+		if err != nil {
+			return nil, err
+		}
+
+		changes := []*pb.LaptopChange{}
+		for _, laptop := range laptops {
+			err = tx.remove(laptop)
+			if err != nil {
+				return nil, err
+			}
+
+			purged = append(purged, laptop.GetId())
+			changes = append(changes, laptopChange(pb.LaptopChange_PURGED, laptop, nil))
+		}
+
+		return changes, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return purged, nil
+}
+
+func (store *txLaptopStore) Watch() LaptopWatch {
+	return &laptopWatch{changes: store.changes.watchLatest()}
+}
+
+func (store *txLaptopStore) WatchChanges(afterSequence uint64) (LaptopChangeWatch, error) {
+	return store.changes.watch(afterSequence)
+}
+
+// searchLaptops evaluates the expression, text relevance and ordering of the
+// query on the candidate laptops, which candidates returns in the order they
+// were created together with their creation sequences. Candidates is given the
+// cursor of the page token to skip the laptops that cannot be on the page.
+// The text relevance is looked up in the text index of the store.
+func (store *txLaptopStore) searchLaptops(query *SearchQuery, candidates func(cursor *searchResult) ([]*pb.Laptop, []uint64, error), found func(*pb.Laptop) error) (string, error) {
+	orderBy := query.ordering()
+	cursor, err := decodePageToken(query.PageToken, orderBy)
+	if err != nil {
+		return "", err
+	}
+
+	match := func(*pb.Laptop) bool { return true }
+	if query.Expression != nil {
+		match, err = compileExpression(query.Expression)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	var relevance map[string]float64
+	if query.Text != "" {
+		store.textMutex.RLock()
+		relevance = store.textIndex.search(query.Text)
+		store.textMutex.RUnlock()
+	}
+
+	laptops, sequences, err := candidates(cursor)
+	if err != nil {
+		return "", err
+	}
+
+	results := []*searchResult{}
+	for i, laptop := range laptops {
+		if relevance != nil && relevance[laptop.GetId()] == 0 {
+			continue
+		}
+		if !match(laptop) {
+			continue
+		}
+
+		results = append(results, &searchResult{
+			laptop:   laptop,
+			key:      sortKey(laptop, orderBy.GetField(), query.Ratings, relevance),
+			sequence: sequences[i],
+		})
+	}
+
+	results = sortSearchResults(results, orderBy, cursor)
+	return sendSearchResults(results, orderBy, query.PageSize, found)
+}
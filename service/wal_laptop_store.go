@@ -0,0 +1,100 @@
+package service
+
+import (
+	"fmt"
+	"gRPC/pb"
+)
+
+// WALLaptopStore is a LaptopStore that keeps its laptops in memory and appends
+// every laptop it writes to a write-ahead log before storing it. The
+// log is compacted into a snapshot every snapshotEvery records.
+type WALLaptopStore struct {
+	*persistentLaptopStore
+	wal *writeAheadLog
+}
+
+// NewWALLaptopStore returns a store logging to the folder, which is created if
+// needed. The snapshot and log already in the folder are replayed first.
+func NewWALLaptopStore(folder string, snapshotEvery int) (*WALLaptopStore, error) {
+	memoryStore := NewInMemoryLaptopStore()
+	wal, err := openWriteAheadLog(folder, snapshotEvery, memoryStore.replay)
+	if err != nil {
+		return nil, err
+	}
+
+	store := &WALLaptopStore{
+		wal: wal,
+	}
+	store.persistentLaptopStore = newPersistentLaptopStore(memoryStore, store)
+	wal.snapshot = memoryStore.snapshot
+	return store, nil
+}
+
+// persist logs the laptops, the log keeps their creation order so the sequences are not needed
+func (store *WALLaptopStore) persist(laptops []*pb.Laptop, _ []uint64) error {
+	records := make([]*pb.StoreRecord, len(laptops))
+	for i, laptop := range laptops {
+		records[i] = &pb.StoreRecord{
+			Record: &pb.StoreRecord_Laptop{Laptop: laptop},
+		}
+	}
+
+	return store.wal.append(records...)
+}
+
+func (store *WALLaptopStore) persistRemoved(laptopIDs []string, _ []uint64) error {
+	records := make([]*pb.StoreRecord, len(laptopIDs))
+	for i, laptopID := range laptopIDs {
+		records[i] = &pb.StoreRecord{
+			Record: &pb.StoreRecord_RemovedLaptopId{RemovedLaptopId: laptopID},
+		}
+	}
+
+	return store.wal.append(records...)
+}
+
+func (store *WALLaptopStore) committed() {
+	store.wal.compactIfDue()
+}
+
+// Close closes the log, the store must not be written afterwards
+func (store *WALLaptopStore) Close() error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	return store.wal.close()
+}
+
+// replay applies a record of the write-ahead log of the store
+func (store *InMemoryLaptopStore) replay(record *pb.StoreRecord) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	switch record := record.GetRecord().(type) {
+	case *pb.StoreRecord_Laptop:
+		store.restore(record.Laptop, 0)
+	case *pb.StoreRecord_RemovedLaptopId:
+		store.unstore(record.RemovedLaptopId)
+	default:
+		return fmt.Errorf("%w: unexpected record %T", ErrCorruptedLog, record)
+	}
+
+	return nil
+}
+
+// snapshot returns the records of all stored laptops
+func (store *InMemoryLaptopStore) snapshot() ([]*pb.StoreRecord, error) {
+	laptops, err := store.all()
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*pb.StoreRecord, len(laptops))
+	for i, laptop := range laptops {
+		records[i] = &pb.StoreRecord{
+			Record: &pb.StoreRecord_Laptop{Laptop: laptop},
+		}
+	}
+
+	return records, nil
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"fmt"
+	"gRPC/pb"
+	"sync"
+)
+
+// WALRatingStore is a RatingStore that keeps its ratings in memory and appends
+// every rating it writes to a write-ahead log before storing it. The
+// log is compacted into a snapshot every snapshotEvery records.
+type WALRatingStore struct {
+	*InMemoryRatingStore
+
+	// mutex serializes the writes so that ratings are logged in the order they change
+	mutex sync.Mutex
+	wal   *writeAheadLog
+}
+
+// NewWALRatingStore returns a store logging to the folder, which is created if
+// needed. The snapshot and log already in the folder are replayed first.
+func NewWALRatingStore(folder string, snapshotEvery int) (*WALRatingStore, error) {
+	memoryStore := NewInMemoryRatingStore()
+	wal, err := openWriteAheadLog(folder, snapshotEvery, memoryStore.replay)
+	if err != nil {
+		return nil, err
+	}
+
+	wal.snapshot = memoryStore.snapshot
+	return &WALRatingStore{
+		InMemoryRatingStore: memoryStore,
+		wal:                 wal,
+	}, nil
+}
+
+func (store *WALRatingStore) Add(laptopID string, score float64) (*Rating, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	rating, err := store.InMemoryRatingStore.Find(laptopID)
+	if err != nil {
+		return nil, err
+	}
+	if rating == nil {
+		rating = &Rating{}
+	}
+	rating.Count++
+	rating.Sum += score
+
+	err = store.write(ratingRecord(laptopID, rating))
+	if err != nil {
+		return nil, err
+	}
+
+	return rating, nil
+}
+
+func (store *WALRatingStore) Delete(laptopID string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	return store.write(&pb.StoreRecord{
+		Record: &pb.StoreRecord_RemovedRatingId{RemovedRatingId: laptopID},
+	})
+}
+
+// write appends the record to the log and then applies it to the ratings in
+// memory, so that readers only see the ratings that were logged. The mutex
+// must be locked.
+func (store *WALRatingStore) write(record *pb.StoreRecord) error {
+	err := store.wal.append(record)
+	if err != nil {
+		return err
+	}
+
+	err = store.InMemoryRatingStore.replay(record)
+	if err != nil {
+		return err
+	}
+
+	store.wal.compactIfDue()
+	return nil
+}
+
+// Close closes the log, the store must not be written afterwards
+func (store *WALRatingStore) Close() error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	return store.wal.close()
+}
+
+func ratingRecord(laptopID string, rating *Rating) *pb.StoreRecord {
+	return &pb.StoreRecord{
+		Record: &pb.StoreRecord_Rating{Rating: &pb.RatingRecord{
+			LaptopId: laptopID,
+			Count:    rating.Count,
+			Sum:      rating.Sum,
+		}},
+	}
+}
+
+// replay applies a record of the write-ahead log of the store
+func (store *InMemoryRatingStore) replay(record *pb.StoreRecord) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	switch record := record.GetRecord().(type) {
+	case *pb.StoreRecord_Rating:
+		store.rating[record.Rating.LaptopId] = &Rating{
+			Count: record.Rating.Count,
+			Sum:   record.Rating.Sum,
+		}
+	case *pb.StoreRecord_RemovedRatingId:
+		delete(store.rating, record.RemovedRatingId)
+	default:
+		return fmt.Errorf("%w: unexpected record %T", ErrCorruptedLog, record)
+	}
+
+	return nil
+}
+
+// snapshot returns the records of all ratings
+func (store *InMemoryRatingStore) snapshot() ([]*pb.StoreRecord, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	records := make([]*pb.StoreRecord, 0, len(store.rating))
+	for laptopID, rating := range store.rating {
+		records = append(records, ratingRecord(laptopID, rating))
+	}
+
+	return records, nil
+}
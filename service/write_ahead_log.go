@@ -0,0 +1,268 @@
+package service
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"gRPC/pb"
+	"gRPC/serializer"
+	"hash/crc32"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+
+	"google.golang.org/protobuf/proto"
+)
+
+var ErrCorruptedLog = errors.New("log is corrupted")
+
+const (
+	logFileName      = "wal.log"
+	snapshotFileName = "snapshot.bin"
+)
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// writeAheadLog appends the records of a store to a log file and compacts the
+// log into a snapshot file. Each record is written as its length as a varint,
+// the CRC-32C of its data and the data, the StoreRecord in binary protobuf.
+type writeAheadLog struct {
+	folder string
+	file   *os.File
+	// size is the size of the log, which a failed append is cut back to
+	size int64
+
+	// appended is the number of records in the log since the last snapshot
+	appended      int
+	snapshotEvery int
+	// snapshot returns the records of the whole state of the store
+	snapshot func() ([]*pb.StoreRecord, error)
+}
+
+// openWriteAheadLog replays the snapshot and then the log of the folder, which
+// is created if needed. A torn record at the end of the log, left by a crash
+// while appending, is cut off. The log is compacted after every snapshotEvery
+// records, or never if snapshotEvery is 0.
+func openWriteAheadLog(folder string, snapshotEvery int, replay func(*pb.StoreRecord) error) (*writeAheadLog, error) {
+	if snapshotEvery < 0 {
+		return nil, fmt.Errorf("invalid snapshot interval %d", snapshotEvery)
+	}
+
+	err := os.MkdirAll(folder, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create log folder: %w", err)
+	}
+
+	// a snapshot is renamed into place when complete, so it must not be torn
+	snapshotPath := filepath.Join(folder, snapshotFileName)
+	data, err := ioutil.ReadFile(snapshotPath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("cannot read snapshot: %w", err)
+	}
+	_, size, err := decodeRecords(data, replay)
+	if err == nil && size < len(data) {
+		err = ErrCorruptedLog
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot replay snapshot: %w", err)
+	}
+
+	logPath := filepath.Join(folder, logFileName)
+	data, err = ioutil.ReadFile(logPath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("cannot read log: %w", err)
+	}
+	count, size, err := decodeRecords(data, replay)
+	if err != nil {
+		return nil, fmt.Errorf("cannot replay log: %w", err)
+	}
+
+	file, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open log: %w", err)
+	}
+
+	if size < len(data) {
+		err = file.Truncate(int64(size))
+		if err == nil {
+			err = file.Sync()
+		}
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf("cannot cut off torn record: %w", err)
+		}
+	}
+
+	return &writeAheadLog{
+		folder:        folder,
+		file:          file,
+		size:          int64(size),
+		appended:      count,
+		snapshotEvery: snapshotEvery,
+	}, nil
+}
+
+// decodeRecords replays the records of the data and returns how many there
+// were and the size they take. A torn final record is not replayed and is not
+// counted in the size. A record that runs past the end of the data is only
+// taken as torn if no valid record follows it, as a damaged length in the
+// middle of the log would otherwise cut off the records after it.
+func decodeRecords(data []byte, replay func(*pb.StoreRecord) error) (int, int, error) {
+	count := 0
+	offset := 0
+	for offset < len(data) {
+		length, n := binary.Uvarint(data[offset:])
+		if n < 0 {
+			return count, offset, fmt.Errorf("%w: invalid record length at offset %d", ErrCorruptedLog, offset)
+		}
+
+		start := offset + n + crc32.Size
+		if n == 0 || start > len(data) || length > uint64(len(data)-start) {
+			if containsRecord(data[offset+1:]) {
+				return count, offset, fmt.Errorf("%w: invalid record length at offset %d", ErrCorruptedLog, offset)
+			}
+
+			// the record was not written completely
+			return count, offset, nil
+		}
+
+		end := start + int(length)
+		checksum := binary.LittleEndian.Uint32(data[offset+n:])
+		if crc32.Checksum(data[start:end], crcTable) != checksum {
+			if end == len(data) {
+				return count, offset, nil
+			}
+			return count, offset, fmt.Errorf("%w: invalid checksum at offset %d", ErrCorruptedLog, offset)
+		}
+
+		record := &pb.StoreRecord{}
+		err := proto.Unmarshal(data[start:end], record)
+		if err != nil {
+			return count, offset, fmt.Errorf("%w: cannot decode record at offset %d: %v", ErrCorruptedLog, offset, err)
+		}
+
+		err = replay(record)
+		if err != nil {
+			return count, offset, err
+		}
+
+		count++
+		offset = end
+	}
+
+	return count, offset, nil
+}
+
+// containsRecord reports whether a complete, non-empty record with a valid
+// checksum starts anywhere in the data
+func containsRecord(data []byte) bool {
+	for offset := range data {
+		length, n := binary.Uvarint(data[offset:])
+		start := offset + n + crc32.Size
+		if n <= 0 || length == 0 || start > len(data) || length > uint64(len(data)-start) {
+			continue
+		}
+
+		end := start + int(length)
+		if crc32.Checksum(data[start:end], crcTable) == binary.LittleEndian.Uint32(data[offset+n:]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func encodeRecords(buffer *bytes.Buffer, records []*pb.StoreRecord) error {
+	header := make([]byte, binary.MaxVarintLen64+crc32.Size)
+	for _, record := range records {
+		data, err := proto.Marshal(record)
+		if err != nil {
+			return fmt.Errorf("cannot encode record: %w", err)
+		}
+
+		n := binary.PutUvarint(header, uint64(len(data)))
+		binary.LittleEndian.PutUint32(header[n:], crc32.Checksum(data, crcTable))
+		buffer.Write(header[:n+crc32.Size])
+		buffer.Write(data)
+	}
+
+	return nil
+}
+
+// append writes the records to the log and syncs it
+func (wal *writeAheadLog) append(records ...*pb.StoreRecord) error {
+	buffer := &bytes.Buffer{}
+	err := encodeRecords(buffer, records)
+	if err != nil {
+		return err
+	}
+
+	_, err = wal.file.Write(buffer.Bytes())
+	if err == nil {
+		err = wal.file.Sync()
+	}
+	if err != nil {
+		// the records may be partly written, which would hide the ones appended next
+		wal.file.Truncate(wal.size)
+		return fmt.Errorf("cannot append to log: %w", err)
+	}
+
+	wal.size += int64(buffer.Len())
+	wal.appended += len(records)
+	return nil
+}
+
+// compactIfDue compacts the log if enough records were appended since the
+// last snapshot. It must be called once the appended records are applied to
+// the store, so that the snapshot includes them. The records are durable
+// already, so a failed compaction is logged and retried after the next append
+// instead of failing the write.
+func (wal *writeAheadLog) compactIfDue() {
+	if wal.snapshotEvery == 0 || wal.appended < wal.snapshotEvery {
+		return
+	}
+
+	err := wal.compact()
+	if err != nil {
+		log.Printf("cannot compact log in %s: %v", wal.folder, err)
+	}
+}
+
+// compact writes a snapshot of the store and then empties the log. Records
+// hold whole items, so replaying a log that was not emptied after a crash
+// over the new snapshot gives the same state.
+func (wal *writeAheadLog) compact() error {
+	records, err := wal.snapshot()
+	if err != nil {
+		return fmt.Errorf("cannot take snapshot: %w", err)
+	}
+
+	buffer := &bytes.Buffer{}
+	err = encodeRecords(buffer, records)
+	if err != nil {
+		return err
+	}
+
+	err = serializer.WriteFileAtomic(filepath.Join(wal.folder, snapshotFileName), buffer.Bytes())
+	if err != nil {
+		return fmt.Errorf("cannot write snapshot: %w", err)
+	}
+
+	err = wal.file.Truncate(0)
+	if err == nil {
+		err = wal.file.Sync()
+	}
+	if err != nil {
+		return fmt.Errorf("cannot empty log: %w", err)
+	}
+
+	wal.size = 0
+	wal.appended = 0
+	return nil
+}
+
+func (wal *writeAheadLog) close() error {
+	return wal.file.Close()
+}
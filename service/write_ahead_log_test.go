@@ -0,0 +1,398 @@
+package service_test
+
+import (
+	"context"
+	"gRPC/pb"
+	"gRPC/sample"
+	"gRPC/service"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+const walLogFile = "wal.log"
+
+// walLaptopState returns the revisions of all laptops of the store, deleted or not
+func walLaptopState(t *testing.T, store service.LaptopStore) map[string]uint64 {
+	state := map[string]uint64{}
+	_, err := store.Search(&service.SearchQuery{Filter: &pb.Filter{MaxPriceUsd: 1e9}, ShowDeleted: true}, func(laptop *pb.Laptop) error {
+		state[laptop.Id] = laptop.Revision
+		return nil
+	})
+	require.NoError(t, err)
+	return state
+}
+
+func logSize(t *testing.T, folder string) int {
+	info, err := os.Stat(filepath.Join(folder, walLogFile))
+	require.NoError(t, err)
+	return int(info.Size())
+}
+
+// crashedFolder returns a new folder whose log is the data cut at the offset
+func crashedFolder(t *testing.T, data []byte, offset int) string {
+	folder := t.TempDir()
+	err := os.WriteFile(filepath.Join(folder, walLogFile), data[:offset], 0644)
+	require.NoError(t, err)
+	return folder
+}
+
+// completeRecords returns how many of the records ending at the sizes fit in the offset
+func completeRecords(sizes []int, offset int) int {
+	n := 0
+	for n < len(sizes) && sizes[n] <= offset {
+		n++
+	}
+	return n
+}
+
+func TestWALLaptopStoreCrashRecovery(t *testing.T) {
+	t.Parallel()
+
+	seed := time.Now().UnixNano()
+	t.Logf("seed %d", seed)
+	random := rand.New(rand.NewSource(seed))
+
+	folder := t.TempDir()
+	store, err := service.NewWALLaptopStore(folder, 0)
+	require.NoError(t, err)
+
+	// every write appends one record, states[i] is the state after the first i records
+	sizes := []int{}
+	states := []map[string]uint64{{}}
+	live := []*pb.Laptop{}
+	for i := 0; i < 40; i++ {
+		switch {
+		case len(live) == 0 || random.Intn(3) == 0:
+			laptop := sample.NewLaptop()
+			err = store.Save(laptop)
+			live = append(live, laptop)
+		case random.Intn(2) == 0:
+			laptop := live[random.Intn(len(live))]
+			laptop.Name = sample.NewLaptop().Name
+			err = store.Update(laptop, laptop.Revision)
+		default:
+			j := random.Intn(len(live))
+			err = store.Delete(live[j].Id, 0)
+			live = append(live[:j], live[j+1:]...)
+		}
+		require.NoError(t, err)
+
+		sizes = append(sizes, logSize(t, folder))
+		states = append(states, walLaptopState(t, store))
+	}
+
+	require.NoError(t, store.Close())
+	data, err := os.ReadFile(filepath.Join(folder, walLogFile))
+	require.NoError(t, err)
+
+	offsets := []int{0, len(data), sizes[0] - 1, sizes[len(sizes)-1] - 1}
+	for i := 0; i < 50; i++ {
+		offsets = append(offsets, random.Intn(len(data)+1))
+	}
+
+	for _, offset := range offsets {
+		crashed := crashedFolder(t, data, offset)
+		recovered, err := service.NewWALLaptopStore(crashed, 0)
+		require.NoError(t, err, "offset %d", offset)
+		require.Equal(t, states[completeRecords(sizes, offset)], walLaptopState(t, recovered), "offset %d", offset)
+
+		// the torn record is cut off, so the records appended after it are replayed
+		laptop := sample.NewLaptop()
+		err = recovered.Save(laptop)
+		require.NoError(t, err)
+		require.NoError(t, recovered.Close())
+
+		reopened, err := service.NewWALLaptopStore(crashed, 0)
+		require.NoError(t, err)
+		other, err := reopened.Find(laptop.Id)
+		require.NoError(t, err)
+		require.NotNil(t, other, "offset %d", offset)
+		require.NoError(t, reopened.Close())
+	}
+}
+
+func TestWALLaptopStoreSnapshot(t *testing.T) {
+	t.Parallel()
+
+	_, err := service.NewWALLaptopStore(t.TempDir(), -1)
+	require.Error(t, err)
+
+	folder := t.TempDir()
+	store, err := service.NewWALLaptopStore(folder, 4)
+	require.NoError(t, err)
+
+	laptops := []*pb.Laptop{}
+	for i := 0; i < 10; i++ {
+		laptop := sample.NewLaptop()
+		err = store.Save(laptop)
+		require.NoError(t, err)
+		laptops = append(laptops, laptop)
+	}
+
+	err = store.Delete(laptops[0].Id, 0)
+	require.NoError(t, err)
+	err = store.Delete(laptops[1].Id, 0)
+	require.NoError(t, err)
+	purged, err := store.Purge(time.Now().Add(time.Second))
+	require.NoError(t, err)
+	require.Len(t, purged, 2)
+
+	laptops[2].Name = "Updated"
+	err = store.Update(laptops[2], 0)
+	require.NoError(t, err)
+
+	// 15 records were appended, the log only keeps the ones since the last snapshot
+	_, err = os.Stat(filepath.Join(folder, "snapshot.bin"))
+	require.NoError(t, err)
+	require.Less(t, logSize(t, folder), 4*proto.Size(laptops[0]))
+
+	state := walLaptopState(t, store)
+	require.Len(t, state, 8)
+	watch, err := store.WatchChanges(15)
+	require.NoError(t, err)
+	watch.Close()
+	require.NoError(t, store.Close())
+
+	reopened, err := service.NewWALLaptopStore(folder, 4)
+	require.NoError(t, err)
+	defer reopened.Close()
+	require.Equal(t, state, walLaptopState(t, reopened))
+
+	// the change log is not persisted, so the changes cannot be resumed after a restart
+	_, err = reopened.WatchChanges(15)
+	require.ErrorIs(t, err, service.ErrChangesTruncated)
+
+	other, err := reopened.Find(laptops[2].Id)
+	require.NoError(t, err)
+	require.Equal(t, "Updated", other.Name)
+
+	// revisions keep increasing after a restart
+	laptop := sample.NewLaptop()
+	err = reopened.Save(laptop)
+	require.NoError(t, err)
+	require.Greater(t, laptop.Revision, laptops[2].Revision)
+}
+
+func TestWALLaptopStoreFailedCompaction(t *testing.T) {
+	t.Parallel()
+
+	folder := t.TempDir()
+	store, err := service.NewWALLaptopStore(folder, 2)
+	require.NoError(t, err)
+	defer store.Close()
+
+	// the temporary snapshot cannot be created over a folder
+	tempPath := filepath.Join(folder, "snapshot.bin.tmp")
+	require.NoError(t, os.Mkdir(tempPath, 0755))
+
+	laptops := []*pb.Laptop{}
+	for i := 0; i < 3; i++ {
+		laptop := sample.NewLaptop()
+		err = store.Save(laptop)
+		require.NoError(t, err)
+		laptops = append(laptops, laptop)
+	}
+
+	_, err = os.Stat(filepath.Join(folder, "snapshot.bin"))
+	require.True(t, os.IsNotExist(err))
+
+	// the compaction is retried after the next write
+	require.NoError(t, os.Remove(tempPath))
+	err = store.Delete(laptops[0].Id, 0)
+	require.NoError(t, err)
+
+	_, err = os.Stat(filepath.Join(folder, "snapshot.bin"))
+	require.NoError(t, err)
+	require.Zero(t, logSize(t, folder))
+}
+
+func TestWALLaptopStoreCorruptedLog(t *testing.T) {
+	t.Parallel()
+
+	folder := t.TempDir()
+	store, err := service.NewWALLaptopStore(folder, 0)
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		err = store.Save(sample.NewLaptop())
+		require.NoError(t, err)
+	}
+	require.NoError(t, store.Close())
+
+	path := filepath.Join(folder, walLogFile)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	// a damaged record followed by others is not a torn write
+	data[10] ^= 0xff
+	err = os.WriteFile(path, data, 0644)
+	require.NoError(t, err)
+
+	_, err = service.NewWALLaptopStore(folder, 0)
+	require.ErrorIs(t, err, service.ErrCorruptedLog)
+}
+
+func TestWALLaptopStoreFailedAppend(t *testing.T) {
+	t.Parallel()
+
+	store, err := service.NewWALLaptopStore(t.TempDir(), 0)
+	require.NoError(t, err)
+
+	laptop := sample.NewLaptop()
+	err = store.Save(laptop)
+	require.NoError(t, err)
+
+	watch, err := store.WatchChanges(0)
+	require.NoError(t, err)
+	defer watch.Close()
+
+	// appending to a closed log fails, the writes must not show in memory
+	require.NoError(t, store.Close())
+
+	other := proto.Clone(laptop).(*pb.Laptop)
+	other.Name = "Updated"
+	err = store.Update(other, 0)
+	require.Error(t, err)
+	err = store.Save(sample.NewLaptop())
+	require.Error(t, err)
+	err = store.Delete(laptop.Id, 0)
+	require.Error(t, err)
+
+	found, err := store.Find(laptop.Id)
+	require.NoError(t, err)
+	require.Equal(t, laptop.Name, found.Name)
+	require.Equal(t, laptop.Revision, found.Revision)
+	require.Len(t, walLaptopState(t, store), 1)
+
+	change, err := watch.Next(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, pb.LaptopChange_CREATED, change.Type)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = watch.Next(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestWALLaptopStoreCorruptedLength(t *testing.T) {
+	t.Parallel()
+
+	folder := t.TempDir()
+	store, err := service.NewWALLaptopStore(folder, 0)
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		err = store.Save(sample.NewLaptop())
+		require.NoError(t, err)
+	}
+	require.NoError(t, store.Close())
+
+	path := filepath.Join(folder, walLogFile)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	// the first record claims to run past the end of the log, but the records
+	// after it are valid, so it was damaged rather than torn
+	require.Equal(t, byte(0x80), data[0]&0x80)
+	data[1] = 0x7f
+	err = os.WriteFile(path, data, 0644)
+	require.NoError(t, err)
+
+	_, err = service.NewWALLaptopStore(folder, 0)
+	require.ErrorIs(t, err, service.ErrCorruptedLog)
+
+	// the log is left as it is for inspection
+	corrupted, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, data, corrupted)
+}
+
+func TestWALRatingStoreCrashRecovery(t *testing.T) {
+	t.Parallel()
+
+	seed := time.Now().UnixNano()
+	t.Logf("seed %d", seed)
+	random := rand.New(rand.NewSource(seed))
+
+	laptopIDs := []string{sample.NewLaptop().Id, sample.NewLaptop().Id, sample.NewLaptop().Id}
+	ratingState := func(store service.RatingStore) map[string]service.Rating {
+		state := map[string]service.Rating{}
+		for _, laptopID := range laptopIDs {
+			rating, err := store.Find(laptopID)
+			require.NoError(t, err)
+			if rating != nil {
+				state[laptopID] = *rating
+			}
+		}
+		return state
+	}
+
+	folder := t.TempDir()
+	store, err := service.NewWALRatingStore(folder, 0)
+	require.NoError(t, err)
+
+	sizes := []int{}
+	states := []map[string]service.Rating{{}}
+	for i := 0; i < 30; i++ {
+		laptopID := laptopIDs[random.Intn(len(laptopIDs))]
+		if random.Intn(5) == 0 {
+			err = store.Delete(laptopID)
+		} else {
+			_, err = store.Add(laptopID, float64(random.Intn(10)+1))
+		}
+		require.NoError(t, err)
+
+		sizes = append(sizes, logSize(t, folder))
+		states = append(states, ratingState(store))
+	}
+
+	require.NoError(t, store.Close())
+	data, err := os.ReadFile(filepath.Join(folder, walLogFile))
+	require.NoError(t, err)
+
+	for i := 0; i < 50; i++ {
+		offset := random.Intn(len(data) + 1)
+		recovered, err := service.NewWALRatingStore(crashedFolder(t, data, offset), 0)
+		require.NoError(t, err, "offset %d", offset)
+		require.Equal(t, states[completeRecords(sizes, offset)], ratingState(recovered), "offset %d", offset)
+		require.NoError(t, recovered.Close())
+	}
+
+	// ratings survive compaction too
+	compacted, err := service.NewWALRatingStore(folder, 2)
+	require.NoError(t, err)
+	_, err = compacted.Add(laptopIDs[0], 5)
+	require.NoError(t, err)
+	state := ratingState(compacted)
+	require.NoError(t, compacted.Close())
+
+	reopened, err := service.NewWALRatingStore(folder, 2)
+	require.NoError(t, err)
+	defer reopened.Close()
+	require.Equal(t, state, ratingState(reopened))
+}
+
+func TestWALRatingStoreFailedAppend(t *testing.T) {
+	t.Parallel()
+
+	store, err := service.NewWALRatingStore(t.TempDir(), 0)
+	require.NoError(t, err)
+
+	laptopID := sample.NewLaptop().Id
+	_, err = store.Add(laptopID, 5)
+	require.NoError(t, err)
+	require.NoError(t, store.Close())
+
+	_, err = store.Add(laptopID, 3)
+	require.Error(t, err)
+	err = store.Delete(laptopID)
+	require.Error(t, err)
+
+	rating, err := store.Find(laptopID)
+	require.NoError(t, err)
+	require.Equal(t, &service.Rating{Count: 1, Sum: 5}, rating)
+}